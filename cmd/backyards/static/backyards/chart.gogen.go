@@ -219,9 +219,9 @@ var Chart = func() http.FileSystem {
 		"/templates/ingress-gateway-deployment.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "ingress-gateway-deployment.yaml",
 			modTime:          time.Date(2019, 1, 1, 0, 1, 0, 0, time.UTC),
-			uncompressedSize: 5766,
+			uncompressedSize: 5800,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\xdd\x6e\xdb\xb8\x12\xbe\xcf\x53\x10\xc6\x39\xc0\x39\x58\x48\xb6\xb3\xcd\xa2\xd5\x9d\x91\x9f\x36\x40\xec\x18\xb1\xb3\xb7\xc6\x84\x1a\x5b\xdc\x50\x24\x97\x1c\xa9\x55\xbb\x7d\xf7\x05\x25\xdb\x91\x68\x3b\xdb\xb8\xc5\x76\x17\xe8\xa5\x39\x33\xdf\xfc\x7d\x33\x22\x0d\x46\xfc\x8a\xd6\x09\xad\x12\x56\x0e\x4f\x1e\x85\x4a\x13\x36\x43\x5b\x0a\x8e\x23\xce\x75\xa1\xe8\x24\x47\x82\x14\x08\x92\x13\xc6\x14\xe4\x98\xb0\x4f\x9f\x98\x50\x5c\x16\x29\xb2\x9e\x50\x2b\x8b\xce\xad\x80\xf0\x3d\x54\xf1\xb2\x90\xd2\x2b\xf5\x58\xcc\x3e\x7f\x5e\x5b\x38\x03\xbc\x31\x8b\xef\x50\x22\x38\x8c\x27\x9b\xe3\x46\x4b\xc2\x03\x4a\xe7\x3d\x30\x06\xc6\x3c\xeb\xa2\x03\x5f\xab\xc7\x8f\xc5\x03\x5a\x85\x84\x2e\x16\xba\xff\x97\x41\x06\x08\x19\xca\x3c\x76\x59\x9f\x67\x60\xa9\x6b\xf8\x00\xfc\xb1\x02\x9b\xba\xb8\x16\x3e\xeb\x36\x07\x05\x2b\x4c\xa3\x87\xaa\x9b\xea\xba\x9c\x87\x0d\x85\x72\x04\x6a\x5f\x85\x0e\xdb\x94\x9b\xae\x79\x93\x73\x1f\x5b\x3c\x32\x66\xdd\x4b\xf6\x07\xb3\x68\xa4\xaf\x6e\xef\xa7\x1e\xeb\x2d\x7a\x87\x81\xb8\xce\x8d\x56\xa8\x28\x61\xdd\x2a\x1d\xd0\x37\x60\x29\xd2\xcb\x43\x65\x6a\x95\x36\x8a\xa2\x93\x36\xbf\xc0\x18\xd7\xdf\x92\xec\x02\x8d\xd4\x55\x8e\x3f\x08\xf6\x83\x60\x47\x12\xcc\x19\xe4\xbe\xa3\xc6\xea\x1a\xf7\x02\x21\x95\x42\xe1\x0c\xb9\x56\xa9\x4b\xd8\x2f\x83\xc1\x09\xab\x23\x15\x1c\x5c\xc2\x86\xf5\xaf\x52\xf8\x14\xde\x09\x47\xda\x56\x37\x22\x17\x94\xb0\xa1\x57\x74\x28\x91\x93\xb6\x0d\x4b\x72\x20\x9e\xdd\xb4\x68\xf3\x62\xe2\x7c\x0b\xea\x1c\xd7\xce\x97\xf7\xe1\xeb\x3a\xc1\x98\x23\x0b\x84\xab\xaa\x29\x95\xd5\x52\x0a\xb5\xba\x37\x29\x10\x6e\xaa\x97\xc3\x87\x59\x61\x57\x98\xb0\xd3\xb3\xff\x3e\x9d\xdd\x2b\x28\x41\x48\x78\x90\x2d\x09\x55\x06\x13\x76\xd7\x86\x39\x61\x8c\x30\x37\x72\x8b\xd8\x5e\x1b\x75\x02\x4a\x69\x02\x12\x5a\x6d\x1b\xc6\x98\xe3\x19\xa6\x85\x44\x1b\x83\x34\x19\x84\x35\xb1\x82\x04\x07\x19\x19\x9d\x26\xac\xd7\x7b\x32\x13\x29\x72\xb0\xb1\x70\x24\x74\x53\xf3\xdf\x90\x53\xc2\x7a\x4b\x90\x0e\x37\x8a\xdc\x62\xed\x70\x2e\x72\x74\x04\xb9\x49\x98\x2a\xa4\x5c\x4b\x65\x87\x3c\x47\xd0\xe7\xdb\x10\xe8\xc8\xfd\xf3\x55\x3b\xe8\x78\xe2\x7e\xc3\x5d\x74\xdc\x1c\x7c\xed\x24\x30\xb6\xd9\x4b\x35\x43\xb4\x22\x10\x0a\x6d\x8b\x07\x11\x03\xbb\x6a\xfd\x6e\xce\x8c\xd5\x1f\xaa\xe0\xcc\xea\x82\xd0\x06\x87\x51\x94\xea\x1c\x84\x0a\x8e\xff\xf3\xbf\xe9\xed\xc5\x62\x32\x1a\x5f\xce\xa6\xa3\xf3\xcb\xff\xc7\xae\xe4\x31\x97\x85\x23\xb4\xb1\xd4\x1c\xe4\x0e\x8e\xd4\xab\x85\x2e\xc8\x14\xb4\x90\x58\x62\xa8\x20\xd4\x52\xef\xfa\xb6\x20\xd4\x45\x61\x6b\xde\x07\xd2\x57\x67\x6e\x47\xdf\x80\x45\x45\xb3\xac\xa0\x54\xbf\x3f\x64\x38\xcc\x07\xbb\x96\x5c\x2b\x85\x9c\xfc\x6c\xe9\x82\x42\x8b\x3d\x06\xae\xe1\xe0\x79\x93\x73\x98\x8c\x9f\xe4\xe8\x40\xc7\xb7\xc1\xfa\x1e\x8c\xd2\x5c\xa8\xa9\xb6\xa1\xcb\xde\xf0\x6c\x30\x18\xf4\x76\xdd\x12\x50\xe1\x0e\x18\x9c\xee\x31\xf0\x9c\xb0\x5a\x4e\x25\x28\x1c\x15\x94\x4d\xb5\x14\x3c\x0c\x66\x7c\x3f\xbf\x1f\xdd\x2c\xe6\x37\xb3\xdd\x16\x08\xc7\x75\x89\xb6\x1a\xa5\xa9\xcf\x66\x6f\xa6\x46\x48\x4d\xcd\xfe\x8a\x5c\xe5\x08\xf3\x64\x78\x36\x18\x0e\x77\xd0\x3e\x0a\xf3\x28\xd4\x7e\xa8\x46\xd6\x45\x79\xf3\xaa\x03\x82\xaa\x0c\x99\xdc\xac\xa8\x0d\x1b\x3b\x42\xc6\x4a\x90\x05\x5e\x59\x9d\x27\x81\x80\xb1\xa5\x40\x99\xde\xe1\x72\x57\xe2\x67\xb2\xf3\x50\xd9\x55\xa8\x8d\xa7\x40\x59\xb2\xfd\x30\xd4\x73\xf9\x6c\x6c\xf5\xa4\x7c\xe7\x00\xeb\x4b\xea\xde\x28\xaf\x27\xb3\xf9\x68\x72\x7e\xb9\xb8\x9e\xfe\xfd\x31\x36\xac\x8e\x8d\x4e\x03\xef\x9b\xe8\xde\xdd\xce\xe6\xdf\x33\xb2\x4c\x3b\x3a\x10\xda\xf5\x6c\x7e\x7d\xbb\x18\x5f\xce\x47\x8b\x7f\x32\x0b\x5b\x61\x9e\xdf\x4e\xae\xae\xdf\xfe\x1b\x38\xf9\x14\xf3\xdd\xed\xfd\xfc\xf2\x6e\x31\xbe\xbd\xd8\x1b\x6e\xc2\x9c\x12\x51\xaa\xa0\xbd\x16\x45\x0e\xfe\x02\x98\x6a\xfe\x88\xb6\xbe\x12\xf8\xd5\xd2\xaf\xf7\x6e\x79\x9a\x0c\xe3\x61\xfc\x3a\x54\x9f\x16\x52\x36\x2b\x32\x61\x23\xf9\x1e\xaa\xf6\x96\x6a\xa2\x5a\xaf\xbc\xe0\x0b\x6a\xb4\xa5\x9d\xcf\xec\xf6\x83\xec\x17\x76\xc2\xea\x15\x1d\x84\xdf\x60\x36\x34\x8b\x4c\xb8\xd7\xeb\xf7\x06\x69\xae\x65\xc2\xe6\xe7\x21\x01\x03\xf8\xd7\xfb\xb1\x33\x22\x73\x7a\x3c\xea\xf0\x6c\xf0\xe6\x30\x70\x84\xaa\xd4\x95\xaf\x46\xfe\x85\x2e\x2c\x42\x2a\x14\x3a\x37\xb5\xfa\x01\xbb\x15\x5b\x82\x90\x85\xc5\x79\x66\xd1\x65\x5a\xa6\x09\xfb\xb9\xeb\xda\xbb\x7c\x8b\x14\xf2\xcf\xd4\xac\xea\x67\x08\x92\xb2\x8f\x7d\xef\xa2\x0a\x55\x0e\x76\xc0\xdf\xda\xeb\x1d\x33\x9f\x77\x4b\x21\x94\x20\x01\xf2\x02\x25\x54\xdb\x67\x5e\x97\xd9\x06\xad\xd0\xe9\x56\xd8\x2d\xb3\x2b\x38\x47\xe7\x5a\xd9\x74\x8d\xa9\xb9\x6c\xec\x85\xb6\xe8\x74\x61\x39\x06\x94\x92\xfe\x05\xe9\xc2\xf4\xb9\x29\x12\x76\x36\x18\x84\x2d\xc8\x31\xd7\xb6\x4a\xd8\xd9\xf0\x74\x2c\x3a\x32\x8b\xbf\x17\xe8\x0e\x20\x0d\x0f\x23\x0d\x4f\x5f\x77\x90\x08\x6d\x2e\x54\x7d\xcb\x1a\xa3\x73\x7e\x80\x9a\x56\xa4\x58\xf6\x5b\x42\x7f\xfb\x7b\xde\x6c\x3d\x73\x57\x42\xb6\x77\x41\xa9\x65\x91\xe3\x58\x17\x6a\x77\xb8\x72\x7f\xba\x76\x87\xc4\xfb\x1c\x2d\xb9\xbd\x44\x6d\x26\x76\x9f\xdc\x33\xe5\x56\xc9\x2a\x61\x64\x8b\x8d\xe3\x54\xb9\x4d\x38\xeb\xeb\xdd\x95\xb0\x6e\x33\x98\xd6\x3f\xbe\x2c\xed\x5d\x12\xdb\x07\xe0\xa4\xf6\x9b\xe2\x12\x0a\x49\xd1\xf6\x78\xa3\x86\xbc\xb0\x82\xaa\x73\xad\x08\x3f\xf8\x47\xd2\xe7\xad\xa4\xfd\x6f\xe8\xcb\xfe\x9b\xda\xb5\x9f\xbc\xf8\xff\xad\xa0\x39\x6f\x2d\x70\x9c\x76\x39\xbe\x9d\xc8\xa6\x37\x9d\xa7\xc6\x73\xe5\x76\xc8\x6d\x38\xb9\xeb\x02\x8d\x75\x8a\x09\x7b\x15\x4c\xa6\x36\x3e\x04\x90\x9d\xde\xb4\xb1\x26\x4f\xde\xe2\x2f\x4f\xf2\xcf\x00\x00\x00\xff\xff\x93\x48\xa6\x64\x86\x16\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\xdd\x6e\xdb\xb8\x12\xbe\xcf\x53\x0c\x8c\x73\x80\x73\xb0\x90\x6c\x67\x9b\x45\xcb\x3b\x23\x3f\x6d\x80\x38\x31\x62\xa7\xb7\xc6\x84\x9c\x58\xdc\x50\x24\x97\xa4\xd4\xba\xdd\xbc\xfb\x82\x92\xed\x48\xb2\x9d\x36\x69\xb1\xdd\x05\x0a\xe7\x26\x9c\xf9\x66\x86\x33\xdf\x0c\x49\xa1\x95\xef\xc9\x79\x69\x34\x83\x72\x78\x70\x2f\xb5\x60\x30\x25\x57\x4a\x4e\x23\xce\x4d\xa1\xc3\x41\x4e\x01\x05\x06\x64\x07\x00\x1a\x73\x62\xf0\xf9\x33\x48\xcd\x55\x21\x08\x7a\x52\x2f\x1c\x79\xbf\xc0\x40\x1f\x70\x99\xde\x15\x4a\x45\xa5\x1e\xa4\xf0\xf0\xb0\x42\x78\x8b\xbc\x86\xa5\xd7\xa4\x08\x3d\xa5\x97\xeb\xe5\x5a\x4b\xe1\x2d\x29\x1f\x3d\x00\xa0\xb5\x4f\xba\x68\x99\xaf\xd4\xd3\xfb\xe2\x96\x9c\xa6\x40\x3e\x95\xa6\xff\xc5\x20\x3b\x16\x32\x52\x79\xea\xb3\x3e\xcf\xd0\x85\x36\xf0\x16\xf9\xfd\x12\x9d\xf0\x69\x25\x7c\xd2\x6d\x8e\x1a\x17\x24\x92\xdb\x65\x7b\xab\xab\x74\xee\x07\x4a\xed\x03\xea\x5d\x19\xda\x8f\x29\xd7\x55\x8b\x90\xe3\x18\x5b\x3a\xb2\x76\x55\x4b\xf8\x13\x1c\x59\x85\x9c\xa0\xf7\x4b\x0f\x7a\xf3\xde\x7e\x43\xdc\xe4\xd6\x68\xd2\x81\x41\x3b\x4b\x7b\xf4\x2d\xba\x90\x98\xbb\x7d\x69\x6a\xa4\x36\x49\x92\x83\x26\xbf\xd0\x5a\xdf\xdf\x90\xec\x84\xac\x32\xcb\x9c\x7e\x12\xec\x27\xc1\x5e\x48\x30\x6f\x89\xc7\x91\x61\x9d\xa9\xec\x9e\x10\x0a\x25\x35\x4d\x89\x1b\x2d\x3c\x83\xdf\x06\x83\x03\xa8\x5a\x41\x72\xf4\x0c\x86\xd5\x7f\xa5\x8c\x7c\x7c\x27\x7d\x30\x6e\x79\x21\x73\x19\x18\x0c\xa3\xa2\x27\x45\x3c\x18\x17\x6d\x02\xe4\x18\x78\x76\xd1\xa0\xcd\xb3\x89\xf3\x3d\xa8\xf3\xb2\x72\x3e\xbf\x0e\xdf\x56\x09\x00\x1f\x1c\x06\x5a\x2c\xeb\xdc\x39\xa3\x94\xd4\x8b\x1b\x2b\x30\xd0\x3a\x7b\x39\x7e\x9c\x16\x6e\x41\x0c\x0e\x8f\xfe\xfb\xb8\x76\xa3\xb1\x44\xa9\xf0\x56\x35\x24\x61\x69\x89\xc1\x75\xd3\xcc\x01\x40\xa0\xdc\xaa\x8d\xc5\xe6\xd8\x88\x3f\xd4\xda\x04\x0c\xd2\xe8\x4d\xc1\x00\x3c\xcf\x48\x14\x8a\x5c\x8a\xca\x66\xd8\xcd\x89\x93\x41\x72\x54\x89\x35\x82\x41\xaf\xf7\x08\x93\x82\x38\xba\x54\xfa\x20\x4d\xcc\x85\xd4\xbf\x13\x0f\x0c\x7a\x77\xa8\x3c\xad\x15\xb9\xa3\xca\xe1\x4c\xe6\xe4\x03\xe6\x96\x81\x2e\x94\x5a\x49\x9b\x33\x67\x95\xe1\xe7\x95\xfe\xfb\x10\xe8\x85\xf3\xe7\x9b\x66\xd0\xcb\x89\xfb\x1d\x67\xd1\xcb\xfa\xe0\x5b\x3b\x01\x60\x3d\x97\xe2\x8f\x1b\x1d\x50\x6a\x72\x0d\x1e\x24\x80\x6e\xd1\xf8\x3f\xfe\x25\x60\x9d\xf9\xf8\x18\x43\xfc\x25\xe0\x4c\x11\xc8\x75\x16\x93\x44\x98\x1c\xa5\xee\x2c\xff\xe7\x7f\x93\xab\x93\xf9\xe5\x68\x7c\x3a\x9d\x8c\x8e\x4f\xff\x9f\xfa\x92\xa7\x5c\x15\x3e\x90\x4b\x95\xe1\xa8\x3a\x80\x24\x51\x66\x31\x37\x45\xb0\x45\x98\x2b\x2a\xa9\xab\x20\xf5\x9d\xd9\xc2\x08\x87\x52\x9f\x14\xae\xe2\x7d\x47\xfa\xea\xc8\x6f\xe9\x5b\x74\xa4\xc3\x34\x2b\x82\x30\x1f\xf6\x01\x87\xf9\x60\x1b\xc9\x8d\xd6\xc4\x43\xec\x2d\x53\x84\x2e\x62\x07\xc0\xd7\x1c\x3c\xae\xf7\xdc\x11\x57\x9d\x9c\xec\xa9\xf8\xda\x42\x55\x83\x91\xc8\xa5\x9e\x18\xd7\x75\xd9\x1b\x1e\x0d\x06\x83\xde\x16\xc8\x07\x0c\x85\xdf\x03\x38\xdc\x01\x88\x9c\x70\x46\x4d\x14\x6a\x1a\x15\x21\x9b\x18\x25\x79\x37\x98\xf1\xcd\xec\x66\x74\x31\x9f\x5d\x4c\xb7\xf0\x42\x7a\x6e\x4a\x72\xcb\x91\x10\x91\xbf\x1d\x85\x7a\xa7\x56\x2a\x13\xd2\xd8\x67\xef\x51\x15\x91\xc6\x71\x39\xdd\xdc\x96\xe0\xe1\x81\x0d\x8f\x06\xc3\xe1\x96\xf9\x4f\xd2\xde\x4b\xbd\xdb\x76\x2d\xfb\x82\xd9\x37\xaf\x5a\x56\x49\x97\x5d\xae\x47\x75\x06\x6b\xbe\xb6\x84\x00\x65\x34\x7c\xe6\x4c\xde\x46\xc5\xdf\x9d\x24\x25\xae\xe9\x6e\x5b\x02\xd0\xbc\x6a\x96\xed\x5d\x35\xc0\x13\x0c\x19\xdb\x1c\x1d\x55\x3a\x9e\x8c\xad\xea\xa5\x1f\x1c\x60\x95\xd8\x9d\x51\x9e\x5f\x4e\x67\xa3\xcb\xe3\xd3\xf9\xf9\xe4\xef\x8f\xb1\xe6\x7d\x6a\x8d\x38\x9f\xec\x8c\xee\xdd\xd5\x74\xf6\x23\x23\xcb\x8c\x0f\x7b\x42\x3b\x9f\xce\xce\xaf\xe6\xe3\xd3\xd9\x68\xfe\x4f\x66\x61\x23\xcc\xe3\xab\xcb\xb3\xf3\xb7\xff\x06\x4e\x3e\xc6\x7c\x7d\x75\x33\x3b\xbd\x9e\x8f\xaf\x4e\x76\x86\xcb\xc0\x6b\x99\x08\x8d\xcd\xc1\x29\x73\x8c\x57\x44\x61\xf8\x3d\xb9\x78\xf6\x56\x03\xa6\x5f\x4d\xe6\xf2\x90\x0d\xd3\x61\xfa\xba\xab\x3e\x29\x94\xaa\x87\x28\x83\x91\xfa\x80\xcb\xe6\xd8\xaa\x33\xb9\x1a\x8a\x9d\x33\xd6\x1a\x17\xb6\x0e\xe2\xcd\x91\x1d\x47\x3a\x83\x6a\x88\xb7\x54\xd6\x36\xeb\x06\x48\x6c\x77\xf2\x43\x3c\xcb\x83\xe1\x46\x31\x98\x1d\x4f\x9e\x36\xff\x7a\xd0\xc1\xd6\xb6\xb3\x10\xec\xe1\xcb\xad\x0e\x8f\x06\x6f\xf6\x1b\x4e\x48\x97\x66\x99\x58\x67\xf2\xaf\x74\xe1\x08\x85\xd4\xe4\xfd\xc4\x99\xdb\xcd\x8d\x7e\x45\x11\x94\xaa\x70\x34\xcb\x1c\xf9\xcc\x28\xc1\xe0\xd7\xb6\xeb\xe8\xf2\x2d\x85\x36\x0a\xc0\x56\xbd\xda\xcf\x08\x55\xc8\x3e\xf5\xa3\x8b\xf6\x21\x08\x60\xf7\x56\x20\xde\xeb\xe3\x76\xde\xcd\x66\xed\x54\x48\x2d\x83\x44\x75\x42\x0a\x97\x9b\x87\x60\x9b\xd9\x96\x9c\x34\x62\x23\x6c\xa7\xd9\x17\x9c\x93\xf7\x8d\xdd\xb4\xc1\xa1\xbe\x8e\xec\x34\xed\xc8\x9b\xc2\x71\xea\x50\x4a\xc5\x37\x66\x67\x0d\x80\xdb\x82\xc1\xd1\x60\xd0\x2d\x41\x4e\xb9\x71\x4b\x06\x47\xc3\xc3\xb1\x6c\xc9\x1c\xfd\x51\x90\xdf\x63\x69\xb8\xdf\xd2\xf0\xf0\x75\xcb\x52\x20\x97\x4b\x5d\xdd\xc3\xc6\xe4\x3d\x2e\xa8\x6e\xf0\xbe\xa0\xb2\xdf\x10\xc6\xfb\xe1\xd3\xb0\x55\xcf\x9d\x49\xd5\x9c\x05\xa5\x51\x45\x4e\xe3\xf8\xf1\xb0\x13\x6b\x02\x79\x5c\x5d\xb9\xa3\xc0\xfb\x9c\x5c\xf0\x3b\x89\x5a\x77\xec\x2e\x79\x64\xca\x95\x56\x4b\x06\xc1\x15\x6b\xc7\x42\xfb\x75\x38\xab\x0b\xe0\x99\x74\x7e\xdd\x98\x2e\x3e\xcf\x5c\xd8\x39\x24\x36\x4f\xc4\xf8\x10\x61\x20\xe8\x0e\x0b\x15\x92\xcd\xf2\x5a\x8d\x78\xe1\x64\x58\x1e\x1b\x1d\xe8\x63\x60\xf0\x79\xfd\xca\xf0\xad\xef\xa5\xed\x47\xc2\x97\xbe\x5e\x6d\xe3\x2f\x9f\xfd\x05\xac\x53\x9c\xb7\x0e\x39\x4d\xda\x1c\xdf\x74\x64\x5d\x9b\x46\x59\x92\x27\xd3\xed\x89\xbb\x6e\xe7\xae\x12\x34\x36\x82\x18\xbc\xea\x74\xa6\xb1\x91\x1f\xa8\x5a\xb5\x69\xda\xba\x7c\xf4\x96\x7e\xfd\x26\xff\x1a\x00\xf6\x71\x35\x5c\xa8\x16\x00\x00"),
 		},
 		"/templates/ingress-gateway-gateway.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "ingress-gateway-gateway.yaml",
@@ -303,9 +303,9 @@ var Chart = func() http.FileSystem {
 		"/templates/tracing-deployment-jaeger.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "tracing-deployment-jaeger.yaml",
 			modTime:          time.Date(2019, 1, 1, 0, 1, 0, 0, time.UTC),
-			uncompressedSize: 3871,
+			uncompressedSize: 4022,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x57\x5d\x73\xda\x38\x14\x7d\xe7\x57\x68\xfc\xba\x83\x19\xba\xdb\xb4\xeb\x37\x17\xbc\x49\xa6\x21\xf1\x1a\x92\xd9\xec\x8b\x47\xd8\x17\x50\x23\x4b\x5a\x49\xf6\xd6\x93\xf0\xdf\x3b\xf2\x07\xc5\x46\x90\xa4\x09\x4f\x60\x9f\x7b\x74\xef\xb9\x47\x57\xe2\xf1\x11\x91\x15\x72\xef\x30\xcd\x41\xb9\x5a\xe2\x84\xb0\xb5\x0b\x0c\x2f\x29\xa4\xdb\xed\x00\xa1\x1a\x01\xff\x1d\x80\x84\xe4\x05\x49\x41\x22\xe7\x1b\x86\x35\x48\x67\xbb\x1d\x60\x41\xee\x40\x2a\xc2\x99\x87\xb0\x10\x6a\x54\x8c\x07\x0f\x84\xa5\x1e\x9a\x82\xa0\xbc\xcc\x80\xe9\x41\x06\x1a\xa7\x58\x63\x6f\x80\x10\xc3\x19\x78\xd5\x1a\x2c\xa1\x79\x0a\xc8\x69\xe9\x57\x39\xa5\xe6\xad\x83\x5c\x54\x25\x62\x7e\x28\x81\x93\x1a\xef\x46\x40\x01\x2b\x70\xaf\xdb\xc7\x35\x8a\xe2\x25\x50\x65\xa8\x91\xc9\xa0\xc6\x1e\xcb\xbc\x8a\xa8\x70\xee\x43\xbe\x04\xc9\x40\x83\x72\x09\x1f\xed\xd2\x7a\x7d\x68\x86\x19\x5e\x43\x3a\x5c\x96\xdd\x3c\xe7\x20\x0b\xd2\x66\x69\x0b\x24\x4c\x69\xcc\x6c\xe5\x1d\x8f\x29\x5a\xb1\x4d\xc8\x64\x83\xa5\x76\x7d\x21\x9a\x16\xa0\x27\x24\x41\x50\x23\x8d\xf3\x9b\x83\x9c\xd8\x39\x4e\x94\xf0\x4c\x70\x06\x4c\x7b\xa8\x29\xf5\x08\x50\x60\xa9\x87\x7c\xd5\xed\xd9\x12\x27\x0f\x25\x96\xa9\x72\x3b\x1d\x43\x68\x03\x34\x73\xd5\x66\x94\x98\xd4\x8e\xc5\x54\x2f\x9b\x20\x25\x20\x31\xcd\x53\x40\x21\xd1\x5c\xd6\x8d\xcc\xb0\x4e\x36\x57\x7b\x9d\x7d\x69\x6f\x35\x64\x82\x62\x0d\x0d\xcd\x9e\xf1\xcc\x87\x76\x18\x5f\xee\x97\x37\x7a\xe6\x4d\xbe\xf9\x55\xef\xbc\xab\x7f\x5e\xe5\xa1\xf7\xf0\xd1\x2f\x79\xa9\x59\x9a\x31\xae\xb1\x26\x9c\xed\x75\x5a\x91\x14\x12\x2c\x5d\xa2\x34\xe1\xb5\x80\xdf\x20\xd1\x1e\x72\x56\x98\x2a\x70\x76\x40\x21\x79\x06\x7a\x03\x79\x95\xb4\x4a\x24\x16\xe0\x99\x21\x95\x1f\x05\x09\x6e\xd2\x73\xc6\x67\x67\x9f\xcf\x9c\xc1\xe3\xe3\xd0\x36\x61\x13\xce\x34\x7c\xd7\x21\xd6\x9b\xfd\x1a\x7b\x4c\x58\x6f\x3c\xe4\x58\x2c\xd5\x0d\x1f\x65\xa0\x25\x49\x54\xbd\x1a\x50\x05\xcf\x72\x8e\x4e\xfb\xb4\xc7\xc8\xd2\x96\xb0\xdd\x9f\xe6\x63\x92\xc0\x84\x81\xdc\x13\x76\x88\x5e\xb5\x0d\x10\x22\x19\x5e\x83\xbd\xc8\xfa\x58\x71\x2b\x84\x2b\x41\x70\x45\x34\x97\x25\xda\x6e\xbd\xe7\xd0\x1a\xaf\xd1\x76\xeb\xf4\xd7\x09\x73\x4a\x43\x4e\x49\x52\x5a\x33\xec\x50\x88\x1d\x16\x3d\xa1\x14\x56\x38\xa7\x1a\x39\x3e\xfd\x1f\x97\xca\xe9\xd6\x60\x3a\xbe\xa7\x41\xad\xc3\x4e\x9d\xb0\xf2\xc3\x9f\x7f\x8c\xc7\xa7\x11\x95\x61\x4e\x43\x3e\x7e\xfa\xf4\xb1\x83\xa8\xba\xab\x79\xc2\xa9\x87\x6e\xa7\xe1\xe9\xe8\xb3\xcf\xbf\x8f\xdf\x14\xfd\xe1\x85\xd1\xc0\x8a\x7d\x35\x5a\x4f\x84\x37\xd3\xf8\xda\x9f\x05\xf3\xd0\x9f\x04\x1d\xaa\xc2\xf4\xe1\x2f\xc9\x33\xaf\xb7\xc2\x8a\x00\x4d\x23\x58\xf5\x9f\x9b\x89\xf2\xf3\xa6\x51\xf4\xcb\x6a\x02\xc3\xca\xeb\xed\xd4\x77\x77\x17\x88\x3d\x74\xb3\x3d\x2d\xd7\x9b\xc6\x0c\x4a\x60\x36\xd7\x5c\xe2\x35\x2c\x4a\x51\xcd\x9a\xd4\x5c\x76\xba\x0e\x68\x4b\xfc\xe2\x4f\xcf\x83\x28\x0e\xc2\x8b\x60\x16\x44\xfe\xd5\x61\x95\x87\x23\xe6\x67\xf4\x3c\xf4\xaf\xe3\xf9\xe2\x26\xf2\xcf\x83\x78\x71\x1f\x5a\x44\xf2\x76\x09\x1c\x5f\x7d\x7a\x19\x05\x93\xc5\x4d\x74\x1f\xdf\xf9\x57\xb7\x76\x92\x51\xcd\x32\x32\xc2\xbc\x88\xea\x6b\x70\x7f\x92\xe8\x01\x4a\xa7\xa7\xeb\xde\xd8\xe8\x92\x4f\x6e\xae\xae\x2a\xda\xf8\xdf\xcb\xf0\xeb\xe5\x75\x7c\xb1\x58\x84\x71\x78\x13\x2d\xac\x4b\x98\xad\x63\xcb\x71\x16\xcc\x4c\x66\x33\xff\x9f\x78\x11\xf9\x93\x60\x6e\x8d\x3e\xbe\xcf\x33\xc8\xb8\x2c\xdd\x0c\x7f\x8f\xcd\x1b\x50\xbd\x91\xd1\xae\xf3\xf7\x6d\x10\xdd\xc7\x5f\xfc\x79\x10\x87\xfe\xe2\xc2\xb6\x8a\xfd\x1a\xdd\x9d\xd2\xb6\x89\x73\x88\x68\xa6\x37\x7a\x66\x40\x57\xd0\xbe\xbe\x94\x14\xc0\x40\xa9\x50\xf2\x25\x74\xb7\xcc\x46\x6b\x71\x0e\xba\xbf\x8f\xea\xd3\x60\xd4\x7f\x6a\x9d\x47\x12\x70\x4a\xde\x9b\xfe\x9d\xf6\x5f\xc1\x69\x9e\xc1\x8c\xe7\xac\x3b\x88\xdb\x16\x1a\x9b\x77\xb2\xc8\x0c\xb4\x9e\x0f\x8d\x83\xfb\x27\x5d\x5b\xb4\xe2\xb9\x4c\x40\x79\xc7\x4e\xf2\x26\xcf\x1d\xb0\x1b\x5f\xfd\x7f\xd2\xfc\x1e\x67\xf4\xf9\xc8\x27\xc4\x08\x4b\x81\x69\x34\xfe\x60\x68\x6c\xa7\xb9\x9d\xf2\x19\x8e\xba\xaa\x77\xd0\xba\xd6\x79\x27\x71\x47\xde\xd3\xfa\x08\x33\xac\x95\xee\x5c\x4c\xea\x47\xc0\xf4\x5d\x45\x3b\xa1\x98\x74\x4e\x80\xc4\x3c\xb8\x3e\xf8\x87\x68\xbb\x25\x0e\xeb\x65\x86\xa2\x48\xac\xb2\x41\x26\x74\x39\x25\xd2\x43\x8f\x07\x9a\xec\xbe\x1e\x7e\xfb\x11\x00\x00\xff\xff\xe1\x94\x26\x56\x1f\x0f\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\x5d\x73\xda\x38\x17\xbe\xe7\x57\x9c\xf1\xed\x3b\x98\xa1\xef\x36\xed\xfa\xce\x05\x6f\xdb\x69\x48\xbc\x40\x33\x9b\xbd\xf1\x08\xfb\x00\x6a\x64\x49\x91\x64\x5a\x86\xf0\xdf\x77\xe4\x0f\x62\x13\x1b\x68\x9b\x21\x17\x44\x3c\xe7\xd1\x39\xcf\xf9\xd0\xd9\xed\x80\x2e\xc1\xbd\x23\x2c\x43\xed\x1a\x45\x62\xca\x57\x2e\x72\xb2\x60\x98\xec\xf7\x3d\x80\x02\x81\x8f\x2f\x40\x52\x89\x0d\x4d\x50\x81\xf3\x8d\xe0\x0a\x95\xb3\xdf\xf7\x88\xa4\x77\xa8\x34\x15\xdc\x03\x22\xa5\x1e\x6c\x86\xbd\x07\xca\x13\x0f\xc6\x28\x99\xd8\xa6\xc8\x4d\x2f\x45\x43\x12\x62\x88\xd7\x03\xe0\x24\x45\x2f\xbf\x83\xc7\x2c\x4b\x10\x9c\x8a\x7e\x99\x31\x66\x7f\x75\xc0\x85\xdc\x11\xfb\x8f\x96\x24\x2e\xf0\xee\x14\x19\x12\x8d\xee\x4d\x75\x5c\xa0\x18\x59\x20\xd3\x96\x1a\xac\x07\x05\xb6\xcb\xf3\xdc\x22\xc7\xb9\x0f\xd9\x02\x15\x47\x83\xda\xa5\x62\x70\x70\xeb\xe7\x4d\x53\xc2\xc9\x0a\x93\xfe\x62\xdb\xf4\x73\x86\x6a\x43\x63\xec\x36\xa4\x5c\x1b\xc2\xdb\xc2\xeb\xb6\xd9\x54\x62\x5b\x93\xd1\x9a\x28\xe3\xfa\x52\x96\x29\x80\x27\x50\x28\x19\x89\x11\x9c\xff\x39\xe0\x44\x4e\x37\x51\x2c\x52\x29\x38\x72\xe3\x41\x19\x6a\x07\x50\x12\x65\xfa\x62\xd9\xcc\xd9\x82\xc4\x0f\x5b\xa2\x12\xed\x36\x32\x06\xb0\x46\x96\xba\x7a\x3d\x88\xad\x6b\x5d\x36\xf9\x8f\xa5\x91\x96\x18\xdb\xe4\x69\x64\x18\x1b\xa1\xec\x77\x80\x94\x98\x78\x7d\x5d\xcb\xec\xa5\xb9\x35\x98\x4a\x46\x0c\x96\x34\xb5\xc2\x03\x68\xd6\xca\xe5\x9c\x00\xed\xca\x5c\x5c\x33\xbf\x55\x37\xbf\x5a\x3b\xaf\x5a\x3f\x3f\x55\x43\xaf\x51\x47\xbf\x54\x4b\xe5\xd5\x9c\x0b\x43\x0c\x15\xbc\x96\x69\x4d\x13\x8c\x89\x72\xa9\x36\x54\x58\x77\x28\xff\x86\xb1\xf1\xc0\x59\x12\xa6\xd1\x39\x00\xa5\x12\x29\x9a\x35\x66\x79\xf1\xeb\x58\x11\x89\x9e\x1d\x52\x59\x27\x48\x0a\x5b\xea\xce\xf0\xea\xea\xfd\x95\xd3\xdb\xed\xfa\x6d\x13\x36\x16\xdc\xe0\x0f\x13\x12\xb3\xae\xc7\x78\xc4\x44\xcc\xda\x03\x67\xb7\x3b\x63\x3e\x48\xd1\x28\x1a\xeb\xe2\x36\x64\x1a\xcf\x72\x0e\x4e\xd7\xe9\x11\x23\x4f\x2a\xc2\xaa\x3f\xed\xc7\xc6\x40\x28\x47\x55\x13\xb6\xff\x3c\xd1\x4f\xd0\x1f\xe0\x00\x34\x25\x2b\x6c\x0f\xb2\x78\x56\xdc\x1c\xe1\x2a\x94\x42\x53\x23\xd4\x16\xf6\x7b\xef\x1c\xda\x90\x15\xec\xf7\xce\xf1\x3d\x61\xc6\x58\x28\x18\x8d\xcb\x1e\x3b\x45\x21\x0f\x58\x78\x82\x04\x97\x24\x63\x06\x1c\x9f\x7d\x27\x5b\xdd\x68\x04\x00\x9b\xf1\x9a\x06\xf6\xaf\xff\xac\x4e\x98\xd7\xc3\x9f\x7f\x0c\x87\xa7\x11\x79\xc1\x9c\x86\xbc\x7d\xf7\xee\x6d\x03\x91\x57\x8c\x11\xb1\x60\x1e\x7c\x1d\x87\xa7\xad\xaf\xde\xff\x7f\xf8\x5b\xd6\x6f\x2e\xb4\x46\xbe\xa9\xab\x51\xd5\x44\x78\x3b\x8e\x6e\xfc\x49\x30\x0b\xfd\x51\x50\xfb\x1d\x60\x63\xf3\xf0\x97\x12\x69\x53\x44\x80\x25\x45\x96\x4c\x71\x79\x7c\x0e\x50\xdf\x34\x36\xc7\x61\x95\x86\xb6\x3d\xbc\xc3\xd4\x77\x0f\x0b\x44\x0d\x5d\xb6\x27\x3e\x76\x15\x83\x96\x84\xcf\x8c\x50\x64\x85\xf3\xad\xcc\x67\x4d\x62\x97\x9d\x66\x05\x54\x21\x7e\xf0\xc7\x1f\x83\x69\x14\x84\x9f\x82\x49\x30\xf5\xaf\x5f\x46\xf9\x72\xc4\x3c\x5b\xcf\x42\xff\x26\x9a\xcd\x6f\xa7\xfe\xc7\x20\x9a\xdf\x87\x2d\x22\x79\x07\x07\xba\x6f\x1f\x7f\x9e\x06\xa3\xf9\xed\xf4\x3e\xba\xf3\xaf\xbf\xb6\x93\x0c\x0a\x96\x81\xdd\xc3\x2e\xa2\xfa\x12\xdc\x9f\x24\x7a\xc0\x6d\x9d\xc7\xea\xfa\x9d\x9a\x75\x97\xac\x0a\x0d\x72\x3b\x94\x4f\xea\x78\x10\x24\x88\xe6\xf3\x56\x31\xed\x20\x80\x27\x78\xcc\x84\x69\x0c\xbd\xc2\x03\xe4\xc9\x05\x87\xd5\xa5\xa3\xdb\xeb\xeb\x3c\xda\xe8\xdf\xcf\xe1\x97\xcf\x37\xd1\xa7\xf9\x3c\x8c\xc2\xdb\xe9\xbc\xed\x66\xc7\x76\x74\x9b\x74\x93\x60\x62\xb5\x9f\xf8\xff\x44\xf3\xa9\x3f\x0a\x66\xad\xd6\xdd\xe3\x27\xc5\x54\xa8\xad\x9b\x92\x1f\x91\x1d\x9d\xa8\x8f\x26\x59\x75\xcf\xdf\x5f\x83\xe9\x7d\xf4\xc1\x9f\x05\x51\xe8\xcf\x3f\xb5\xdd\xb2\xdb\x9d\x7f\x7b\xe0\xec\xf3\x62\x11\xe5\xa3\x02\x67\xde\x8d\x1c\x7a\xac\x2f\xa3\x1b\xe4\xa8\x75\xa8\xc4\xa2\xdc\xc4\xaa\xcf\xda\x18\xf9\x11\xcd\x71\x7b\xcb\xbc\x71\x07\xc7\xa7\xad\x63\x52\x21\x49\xe8\x6b\xd3\xbf\xd2\x58\xd8\x08\x96\xa5\x38\x11\x19\x6f\xbe\x0f\x55\x0a\x6d\xf7\xd5\x8e\x01\x52\x0b\x2d\xc6\x56\xd9\x58\xc7\x0f\x70\x15\xb4\x16\x99\x8a\x51\x7b\x5d\x0b\x46\xe9\xe7\x01\xd8\xb4\xb7\x9d\x00\x46\xdc\x93\x94\x9d\xb7\x7c\x02\x4e\x79\x82\xdc\xc0\xf0\x8d\xa5\x69\x5b\x32\xda\x29\xcf\x70\x14\x51\xbd\x82\xd6\x85\xce\x07\x89\x1b\xf2\x9e\xd6\x47\xda\x37\x44\x9b\x7a\x28\xe5\x11\x72\x73\x97\xd3\x8e\x18\xa1\x8d\x87\x29\xb6\x07\x37\xd5\x9a\x73\x72\x79\xed\x17\x21\xf4\xe5\x26\x6e\x95\x0d\x53\x69\xb6\x63\xaa\x3c\xd8\xbd\xd0\xe4\xf0\xf5\xf9\x10\x90\x27\xb0\xdf\xf7\xfe\x1b\x00\x56\x67\x7a\xc4\xb6\x0f\x00\x00"),
 		},
 		"/templates/tracing-permissive-mtls.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "tracing-permissive-mtls.yaml",
@@ -317,9 +317,9 @@ var Chart = func() http.FileSystem {
 		"/templates/tracing-pvc.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "tracing-pvc.yaml",
 			modTime:          time.Date(2019, 1, 1, 0, 1, 0, 0, time.UTC),
-			uncompressedSize: 926,
+			uncompressedSize: 1008,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\x3f\x8f\xd4\x30\x10\xc5\xfb\xfd\x14\xa3\xb4\x28\x3e\x51\xd0\xa4\x43\x57\x50\x71\x42\x20\x6d\x8b\x26\xf6\xbb\x5d\xb3\x8e\xed\xf3\x38\x91\x4e\xcb\x7e\x77\xe4\xc4\x59\xe0\x6e\x03\xba\x2e\xf1\xbc\x9f\xe7\xcd\x1f\x9f\xcf\x2d\xd9\x47\x52\x7b\x76\x23\x44\xe5\xc4\xda\xfa\x83\x82\xe7\xde\xc1\xd0\xe5\xb2\xab\x0a\x3c\xbd\x12\xc5\x14\x26\x6b\x90\xa8\xf9\xc1\x38\x20\x35\x7f\xc8\x5f\x6a\x17\x85\x8a\x48\x62\x25\x17\xe1\xc9\x7a\xd3\xd1\x97\xe5\x00\x3e\xef\x83\x1b\x07\xdc\x3b\xb6\xc3\x8e\xa3\xdd\x97\x40\xf0\x1d\x4d\xef\x77\x03\x32\x1b\xce\xdc\xed\x88\x3c\x0f\xe8\xe8\x7c\x26\xeb\xb5\x1b\x0d\xa8\xe9\x59\x9f\x9e\x39\x19\x51\x25\xd6\x90\xa2\xcb\xa5\x5d\xf2\xb5\x71\xd2\x95\x91\xc8\x7a\x01\xd5\x57\x38\xb0\x40\x3d\xac\xc7\xc5\x0e\x91\xe3\x1e\x4e\x4a\x0e\x22\x8e\x71\xd1\x6e\x95\x3c\x13\xb3\x4e\x9d\xc6\x1e\xc9\x23\x43\x94\x0d\x77\x57\x7f\x6f\x47\x07\xf6\x7c\x80\x69\xfb\xe7\xbf\x7d\x7e\x43\x9a\xec\xea\xf2\x16\x68\xbd\x64\xf6\xb7\xca\xdb\x66\xa6\xb5\xbf\x05\xb9\x3f\x72\xca\xea\x63\x8c\xb5\xeb\xf4\x93\x12\xa2\x2b\xad\x69\xde\x35\xd4\x7c\x6f\xb6\x2f\xd2\x61\x88\xc1\xc3\xe7\x8e\x6a\xa9\x1b\xc2\xc8\x29\xb7\xe1\xf1\xff\xc3\x9b\xf9\x23\xdc\xa0\xe4\x78\xa7\x8b\xb5\x2d\x66\x0e\x56\x48\x22\x74\x19\x9e\xe4\x90\xf8\x50\x16\x49\xe4\x61\x6b\x18\x75\x1d\x5f\x6a\x97\xe4\xac\x35\x44\x3e\x07\x83\xba\x0d\xed\x3f\xae\xf8\x2d\x5e\xe0\x04\x09\x63\xd2\x2b\x9a\xf0\x34\x42\x72\xfd\xbb\xba\xeb\xe8\xc3\x27\x3b\xbf\x15\xf8\xeb\x2b\x7b\xfd\xf9\x2b\x00\x00\xff\xff\x3d\x9b\x17\xba\x9e\x03\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\x4f\x8f\xd3\x30\x10\xc5\xef\xfd\x14\xa3\x5c\x51\xbc\xe2\xc0\x25\x37\xb4\x07\x4e\x20\xc4\x4a\xbd\xa2\x89\xfd\xda\x9a\x3a\xb6\xd7\xe3\x04\x2d\xdd\x7e\x77\xe4\xc4\xed\xc2\xd2\x80\xb8\x25\xf6\xfb\xcd\xbc\xf9\xe3\xd3\xa9\x25\xbb\x23\xb5\x65\x37\x42\x54\x4e\xac\xad\xdf\x2b\x78\xee\x1d\x0c\x9d\xcf\x9b\xaa\xc0\xe3\x1f\xa2\x98\xc2\x64\x0d\x12\x35\xdf\x18\x7b\xa4\xe6\x17\xf9\x6b\xed\xa2\x50\x11\x49\xac\xe4\x22\x3c\x5a\x6f\x3a\xfa\xbc\x1c\xc0\xe7\x6d\x70\xe3\x80\x7b\xc7\x76\xd8\x70\xb4\xdb\x72\x11\x7c\x47\xd3\xdb\xcd\x80\xcc\x86\x33\x77\x1b\x22\xcf\x03\x3a\x3a\x9d\xc8\x7a\xed\x46\x03\x6a\x7a\xd6\xc7\x27\x4e\x46\x54\xb9\x6b\x48\xd1\xf9\xdc\x2e\xf9\xda\x38\xe9\xca\x48\x64\xbd\x80\xea\x0b\x1c\x58\xa0\x3e\x5d\x8e\x8b\x1d\x22\xc7\x3d\x9c\x94\x1c\x44\x1c\xe3\xa2\x5d\x2b\x79\x26\x66\x9d\x3a\x8e\x3d\x92\x47\x86\x28\x1b\xee\xae\xfe\xfe\x1f\x1d\xd8\xf3\x1e\xa6\xed\x9f\x7e\xf7\xf9\x80\x34\x59\x8d\x75\xd0\x7a\xc9\xec\x6f\x95\xb7\xce\x4c\x97\xfe\x16\xe4\xfe\xc0\x29\xab\xf7\x31\xd6\xae\xd3\x33\x25\x44\xc7\x1a\xd4\xbc\x69\xa8\xf9\xda\xac\x07\xd2\x61\x88\xc1\xc3\xe7\x8e\x6a\xa9\x2b\xc2\xc8\x29\xb7\x61\xf7\xef\xe1\xcd\xfc\x01\x6e\x50\x72\xb8\xd3\xc5\xda\x1a\x33\x5f\x56\x48\x22\x74\x19\x5e\x59\xc0\xef\x36\x1f\xd6\x56\x50\x72\x48\xbc\x2f\x8b\x26\xf2\xd2\xa2\xd7\xa7\x73\xc6\x6a\xa6\x44\x84\x9f\x1f\x03\x11\x6b\x0d\x91\x8f\xc1\xa0\xae\x4a\x7b\x6b\xd8\x35\xd7\x8b\x78\x81\x13\x24\x8c\x49\x5f\xd0\x84\xc7\x11\x92\xeb\xdf\xd5\x44\xf7\x97\x88\x55\xf2\x60\x7f\x80\x9e\xc9\x60\xc7\xa3\xcb\xd4\xbc\xfb\x60\xaf\xaf\xaf\x5a\xbd\xfd\xf9\x73\x00\x43\x46\xa7\xab\xf0\x03\x00\x00"),
 		},
 		"/templates/tracing-service-jaeger.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "tracing-service-jaeger.yaml",
@@ -345,9 +345,9 @@ var Chart = func() http.FileSystem {
 		"/values.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "values.yaml",
 			modTime:          time.Date(2019, 1, 1, 0, 1, 0, 0, time.UTC),
-			uncompressedSize: 3698,
+			uncompressedSize: 3820,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x57\x4f\x8f\xdb\xb8\x15\xbf\xf3\x53\x3c\x38\x87\xbd\x8c\x65\x3b\x69\xbb\xbb\x3a\xb5\x18\x60\x81\xa0\x98\x66\x30\x49\x90\x43\x11\x74\x9f\xa9\x27\x99\x33\xd4\xa3\x4a\x3e\xda\xa3\x14\xfd\xee\x05\x49\xd9\x96\x27\x33\xc5\xe4\xb2\x27\x49\xe4\xfb\xfb\x7b\x7f\xc5\xd8\xd3\x87\x3d\x79\x6f\x1a\xaa\x61\xb1\x50\x6d\xb4\xf6\xbb\x43\xe5\x69\xb0\x46\xe3\xb5\x8b\x2c\x35\x6c\x94\x32\xdc\x79\x0a\xa1\x56\x00\xc4\xb8\xb5\xd4\xd4\xd0\xa2\x0d\xa4\x00\x90\xd9\x09\x8a\x71\x9c\xef\x01\xfe\xf3\xdf\xfc\x78\x03\x0f\x71\x4b\x9e\x49\x28\x54\xc6\xad\x26\x19\x95\xb6\x18\x42\x0d\x26\x88\x71\xcf\x12\x8a\x0d\x4b\xd4\x7d\xb2\x45\x7c\xa4\x85\x02\x18\x50\x76\x93\x74\x1c\xb2\x6d\x49\x5f\x0d\x2b\x1c\x4c\x3e\x3d\xd0\xb6\x86\x95\x02\xd8\x62\xa0\x5b\x94\x5d\xf9\xda\xb9\x20\xa1\x86\x7f\x7e\x55\x00\x62\x8f\x6f\x6f\x00\x96\x10\x48\x7b\x92\x7f\x60\xd2\xa3\x77\xe8\x65\x49\x8f\xd8\x0f\x96\x96\x62\x43\x21\x3a\xf2\x1f\xbf\x12\xdb\x05\x69\x65\x9d\x46\xab\x14\x46\x71\x41\xa3\x35\xdc\x5d\x40\x94\xcc\x57\x00\xbd\xe1\xbb\x82\x68\x48\x68\x02\xf4\xf8\x78\x3e\x78\x97\x6c\x43\xdf\x91\x5c\xdf\x7e\xfe\x2c\xc6\x9a\x6f\xd9\xbb\x5b\xf2\x9a\x58\xb0\xa3\x1a\x7e\x5e\x9f\x88\x6e\xa8\x77\x7e\x7c\x99\x4e\x79\x0a\x2e\x7a\x4d\xd9\xee\x1c\x8b\x37\xf0\x85\x20\x86\x88\xd6\x8e\xe0\x49\xbb\xbe\x27\x6e\x80\x9d\x80\x38\x08\x03\x69\xd3\x8e\xd0\x50\x8b\xd1\x0a\x9c\xf8\x01\xb9\x49\x04\x96\x70\x4f\x20\x3b\x13\x00\x03\x20\x68\xc7\x41\x1b\x17\x0b\x4a\x7a\xe7\x8c\x26\x68\x9d\x07\xd9\x25\x35\xe4\x2b\xf8\x94\x89\x6d\x70\x60\x58\x7b\xc2\x40\x21\x21\xc7\xba\x3c\xbd\x04\xf0\x91\xc1\x31\x10\xef\x8d\x77\xdc\x13\x4b\x80\x83\x91\x1d\x58\x23\x62\x29\xcb\x3e\x99\x72\x05\x21\xea\x5d\x52\x7f\x63\xd8\xa4\x74\xa9\xe0\x7d\x0b\xa3\x8b\xd0\x38\x38\x20\x5f\x78\x32\x63\x8b\x5c\xbc\x95\x6c\x5c\xeb\xac\x75\x07\xc3\x5d\x96\x6e\x0d\x27\x12\x6c\xee\x63\xc8\xf7\x7d\x52\xc0\xa4\x29\x04\xf4\xe3\x55\xf6\xdf\x53\xef\xb2\xf7\x04\x3a\x7a\x3b\xc2\xd6\x63\xc6\xa6\x15\xf2\xf0\xd3\x19\xec\x9f\xaa\x49\x68\x6f\x4e\x29\xa3\x87\x58\xc3\x66\xbd\xee\xcb\x67\x9f\x43\x57\xc3\xe6\xed\x2f\x37\x66\x72\xf0\xdf\x91\xc2\xeb\xe8\x15\xbb\x86\x3e\x92\x25\x2d\xce\xd7\x29\xb2\x4a\x9c\x25\x3f\x95\x5e\x4a\x6d\x85\x6d\x6b\xd8\xc8\x58\xae\xe7\xa5\xa2\x00\x4c\x9f\x72\x24\x97\x8b\xa7\xc1\x05\x23\x59\xfa\x16\xf9\x1b\x1a\x6d\x5d\x6c\x56\x5b\xd4\x0f\x23\xfa\x26\x64\x2a\xc1\xae\x86\x75\xb5\xa9\x36\xef\xf2\xf7\x10\xad\xbd\x75\xd6\xe8\xb1\x86\xbf\xd9\x03\x8e\x41\xe5\x6c\xdf\xd7\x25\xcf\x88\xf7\x1f\x73\x55\x1d\x0b\x8d\x78\x7f\xed\xb8\x35\xdd\x0d\x0e\xb3\xb3\xbb\x09\xb4\xdf\x0c\xd9\xa6\x18\x0e\x10\xc8\xef\x8d\x9e\xec\x93\x71\xa0\x1a\xae\x6d\x0c\x42\xfe\xfd\x6d\xd1\xee\xbc\xd4\xf0\xcb\x5a\xa9\x54\xec\xcf\x75\xa2\x1f\x71\x70\x79\xa0\xed\x1f\xef\xe4\x39\x5d\x72\x80\x7e\xc8\xeb\xdc\x30\x13\xe1\xf5\x5d\xe9\x5a\x3d\x85\x9d\x02\x48\xed\x3b\x0c\xa8\x69\xea\xa9\xcb\x30\x06\xa1\x5e\xa9\xc1\xbb\x9e\x64\x47\xf1\xf9\xbe\x9d\x7a\x5b\x0d\x67\x22\x05\x10\xbd\xad\xb3\x95\xf3\x66\xf5\x02\xaa\x89\x71\x75\xc1\x3d\x61\xb9\x7f\x5b\x6d\x36\xd5\xe6\x25\x30\xe7\x20\x5c\x8e\x8b\x73\xe9\x94\x7e\x9b\xab\xe1\x6d\xa9\x86\x72\x72\x2c\x88\x3f\xaf\x6f\xcc\x74\x38\xaf\xa0\x19\xdb\xe6\x39\xb6\x77\x85\x4d\xe7\x68\x15\x86\xce\xba\x2d\xda\xf2\x0e\x10\xb4\xc7\x81\xde\xb3\x90\xdf\xa3\x4d\x52\xc2\xc5\xcd\x27\xd3\x93\x8b\x32\xbf\xa0\x3d\xda\x98\x4b\xec\x29\xdb\x2b\x43\xfb\xeb\xfa\xd7\xb5\x52\x9d\xc7\x16\x19\x9f\x9b\x1e\xaf\x8a\xc6\xc4\xbf\x9a\x9e\xe7\x68\xfc\xa5\x7a\x57\xad\x5f\x13\x8b\x12\x87\x40\x3a\xfa\xd4\x40\x32\xcb\x93\x9c\x51\xe2\x51\xbf\x30\xe3\xe8\x51\xc8\x33\xda\xcf\xde\xd6\xb0\xba\x47\xea\xc8\xa7\xc1\xed\xdd\xde\x34\xe4\x6b\x38\x1d\x95\x97\xa2\x60\xe6\xcd\xa5\x3f\x8d\xd3\x0f\xe4\xd3\x36\x50\xc8\x27\xcd\x2b\xb4\x76\x69\x78\xe9\x98\x26\xa6\xec\xe4\xa6\xda\xbc\x9d\xbe\x9f\xf5\xf2\x94\x02\x13\x51\x8f\x8f\xff\x92\xdc\xc7\x53\x2e\xad\xd7\xeb\x29\x55\xc2\x80\xfc\x51\x9c\xc7\x8e\x3e\x8d\x03\x41\x8a\x2d\x81\x46\x86\x2d\xc1\xa2\xc8\x58\xe4\xb1\xb0\xd8\x62\xd3\x91\x5f\xe4\xb9\x77\x36\xaa\x38\x94\xa5\x3d\x91\x95\xda\x50\x53\x00\x00\x18\xc8\x07\x93\xaa\xef\x58\x8b\x00\xa1\x90\x5e\xa7\xe5\xa8\x94\xf7\x62\x51\xf6\x1d\x9d\xe6\xd1\x8d\x4b\xbb\xd9\x1d\x61\xf3\xc5\x1b\xa1\x1b\xe4\xf1\x69\x8a\xcd\xf7\xb0\x63\x51\x71\x96\x74\x6e\x7a\xdf\xcc\xf0\x60\xf8\xc5\x8c\x3c\x06\xf1\xb6\x64\xe6\x9f\x36\x1b\xa5\x4a\x89\x19\x9f\x26\x37\x79\xe9\x91\xb1\x23\x9f\xc6\xed\x96\x60\xf0\x14\xd2\x70\x4d\x90\xb4\x91\x75\xd2\x8e\xf6\xb4\x0c\xa0\x96\x88\xf6\xdc\xa1\x14\xc6\xc6\x48\x30\xfc\xf0\x23\xfd\xbb\xb5\x91\x58\x56\xe5\xd1\xcc\x1a\xcd\xa6\xfa\x79\xf9\xda\x3e\x33\x1f\x95\x69\x03\xcb\x78\xee\x44\x86\xd4\x09\x45\x86\xa9\x4c\x4f\x05\x1e\x26\x33\xc4\x8f\x5f\xd0\xc8\x8d\xe1\x9c\x2a\xfd\xd3\x73\x7c\xbc\x24\xce\x07\xd3\x24\x41\x36\xfa\x03\xff\x86\xc6\x46\x4f\xa7\x22\x9a\xf6\xe0\x0e\x85\x0e\x98\x53\xf2\xff\x76\x0a\x35\x43\xbd\xac\x09\x77\x25\x1e\x0d\x98\x16\x4e\x80\x82\x09\x47\x40\xab\xef\xb1\x4d\x1b\xea\xae\x70\x7f\x60\x02\xd7\x02\xb2\xe3\xb1\x77\x31\x80\xf3\x60\xfa\x94\x91\x8e\x33\x3e\x89\x3d\x35\x75\xd7\xd4\x67\xaa\xcc\xfa\x69\x47\x70\x7f\x10\x08\xa6\x63\xc3\x1d\x3c\xd0\x08\x07\x63\x6d\xca\x84\x8e\x38\xe1\x4b\x0d\xb4\xde\xf5\x39\xfa\x93\x5b\x29\x83\xd3\x8f\x04\x88\x7b\x20\xbe\xca\x92\xb6\x51\x8e\x65\xe5\xca\x9f\x47\x43\x9c\x72\x2a\x06\xba\xdc\xd4\xa6\x3d\x3d\x2f\x57\xf7\x07\xf9\x58\x54\xff\x9d\xc6\x32\x84\xa7\xc5\x29\xe7\x79\x79\x7d\xa0\xb1\x56\xea\xc2\xa3\x67\x93\xed\x0d\xbc\x9f\xd3\x24\xfc\xca\x60\x88\x09\xda\xed\x79\x1f\x16\x97\x0a\xdc\x1d\xe6\x28\x71\x07\xc8\x63\x5e\x76\x57\x9d\x77\x71\x58\x05\x04\xc3\xc5\xed\x3c\x7e\xcb\x2e\x9b\x88\x82\x76\x03\x7d\xe7\xb6\x8e\x41\x5c\x6f\xbe\x51\x53\x28\x13\xe7\xef\xc5\x80\xdf\xa1\x4d\x3b\x43\x35\xed\x84\xa7\x69\x95\xff\x41\x92\xca\xb4\x65\x2c\xee\x91\xa9\x6a\x1c\xfd\xf5\xf8\x2f\xa2\x5d\xbf\xf8\x7a\x24\xcb\x46\x65\xba\x86\xf6\x64\x5d\xb2\x7c\x71\xb5\xc0\xa6\x37\x1c\xce\x64\x53\x88\xa6\x08\x65\xfa\x62\x7e\x7d\x79\x53\x4f\x60\x1c\x9f\x33\x09\xc9\xbb\xcc\xb8\x37\x74\x58\x5c\xc1\x51\x61\x5a\xba\x17\x5f\xd5\xff\x02\x00\x00\xff\xff\xa8\x7d\xd0\xca\x72\x0e\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x57\x4b\x6f\xe3\xc8\x11\xbe\xf7\xaf\x28\xc8\x87\xbd\x58\x94\xe4\xc9\x64\x76\x79\x4a\x60\x60\x83\x41\xe0\x8c\x61\xcf\x60\x0e\xc1\x22\x5b\x6a\x16\xa9\xb6\x9a\xdd\x4c\x3f\x24\xd3\x41\xfe\x7b\x50\xdd\x4d\x3d\x3c\x32\xe0\xbd\xe4\x44\xb2\xbb\xde\x55\x5f\x55\xd1\x60\x4f\x5f\x76\xe4\x9c\x6a\xa8\x86\xd9\x4c\xb4\x51\xeb\x1f\x0e\x85\xa3\x41\x2b\x89\xb7\x36\x9a\x50\xc3\x4a\x08\x65\x3a\x47\xde\xd7\x02\x80\x0c\xae\x35\x35\x35\xb4\xa8\x3d\x09\x00\x34\xc6\x06\x0c\xca\x9a\x74\x0f\xf0\x9f\xff\xa6\xc7\x15\x6c\xe3\x9a\x9c\xa1\x40\xbe\x52\x76\x51\x64\x54\x52\xa3\xf7\x35\x28\x1f\x94\xbd\x48\x18\xb4\x9f\xa3\xec\xd9\xc0\xe0\x22\xcd\x04\xc0\x80\x61\x53\xa4\xe3\x90\x6c\x63\x7d\x35\x2c\x70\x50\xe9\x74\x4f\xeb\x1a\x16\x02\x60\x8d\x9e\xee\x31\x6c\xf2\xd7\xc6\xfa\xe0\x6b\xf8\xe7\x6f\x02\x20\xe8\xe9\xed\x0a\x60\x0e\x9e\xa4\xa3\xf0\x0f\x64\x3d\x72\x83\x2e\xcc\xe9\x19\xfb\x41\xd3\x3c\x68\x9f\x89\x26\xfe\xe9\x8b\xd9\xce\x48\x2b\x6d\x25\x6a\x21\x30\x06\xeb\x25\x6a\x65\xba\xb3\x10\xb1\xf9\x02\xa0\x57\xe6\x21\x47\xd4\x73\x34\x01\x7a\x7c\x3e\x1e\x7c\x60\xdb\xd0\x75\x14\x6e\xef\xbf\x7d\x0b\x4a\xab\x97\x14\xcd\x7b\x72\x92\x4c\xc0\x8e\x6a\xf8\xb4\x3c\x10\xdd\x51\x6f\xdd\xf8\x36\x9d\x70\xe4\x6d\x74\x92\x92\xdd\x29\x17\x57\xf0\x9d\x20\xfa\x88\x5a\x8f\xe0\x48\xda\xbe\x27\xd3\x80\xb1\x01\x82\x05\x3f\x90\x54\xed\x08\x0d\xb5\x18\x75\x80\x03\x3f\xa0\x69\x98\x40\x13\xee\x08\xc2\x46\x79\x40\x0f\x08\xd2\x1a\x2f\x95\x8d\x39\x4a\x72\x63\x95\x24\x68\xad\x83\xb0\x61\x35\xe4\x2a\xf8\x9a\x88\xb5\xb7\xa0\x8c\x74\x84\x9e\x3c\xc8\x0d\x1a\x96\x9a\x22\xe8\xc1\x45\x03\xd6\x00\x99\x9d\x72\xd6\xf4\x64\x82\x87\xbd\x0a\x1b\xd0\x2a\x04\xcd\x85\x75\x75\x34\xe5\x1a\x7c\x94\x1b\x56\x7f\xa7\x8c\xe2\xba\xaa\xe0\x73\x0b\xa3\x8d\xd0\x58\xd8\xa3\x39\xf3\xe4\x84\x2d\x9a\xec\x6d\x48\xc6\xb5\x56\x6b\xbb\x57\xa6\x4b\xd2\xb5\x32\x2c\x19\x9b\xa7\xe8\xd3\x7d\xcf\x0a\x0c\x49\xf2\x1e\xdd\x78\x9d\xfc\x77\xd4\xdb\xe4\x3d\x81\x8c\x4e\x8f\xb0\x76\xc8\x5e\x60\x1b\xc8\xc1\x4f\x07\x55\xf5\x4f\x55\x11\xda\xab\x43\xc9\xc8\x21\xd6\xb0\x5a\x2e\xfb\x74\x05\x7d\x4a\x5d\x0d\xab\x9b\x9f\xef\x54\x71\xf0\xdf\x91\xfc\xfb\xe8\x85\xb1\x0d\x3d\x92\x26\x19\xac\xab\x19\x65\x22\x58\x4d\xae\x40\x8f\x4b\x5b\x60\xdb\x2a\xa3\xc2\x98\xaf\x4f\xa1\x22\x00\x54\xcf\x35\x92\xe0\xe2\x68\xb0\x5e\x85\x24\x7d\x8d\xe6\x05\x95\xd4\x36\x36\x8b\x35\xca\xed\x88\xae\xe1\xd4\x72\xc1\x75\x35\x2c\xab\x55\xb5\xe2\x1a\x05\x18\xa2\xd6\xf7\x56\x2b\x39\xd6\xf0\x57\xbd\xc7\xd1\x8b\x54\xed\xbb\xa4\x2e\xbd\x3d\x26\x54\x4d\x40\x23\xb3\xbb\xb5\xa6\x55\xdd\x1d\x0e\x27\x67\x0f\x25\x68\xbf\x2a\xd2\x4d\x3a\x16\x00\x9e\xdc\x4e\xc9\x62\x5f\x18\x07\xaa\xe1\x56\x47\x1f\xc8\x7d\xbe\x4f\x67\x83\x75\xa1\x86\x9f\x97\x42\x30\xd8\x2f\x75\xa2\x3f\xe2\xe0\x7c\x4f\xeb\xff\xbf\x93\xc7\x72\xe1\x88\xfd\x31\xaf\x53\xc3\x64\xc2\xdb\x87\xdc\xb5\x7a\xf2\x1b\x01\xc0\xed\xdb\x0f\x28\xa9\xf4\xd4\xb9\x1f\x7d\xa0\x5e\x88\xc1\xd9\x9e\xc2\x86\xe2\xe5\xbe\xcd\xbd\xad\x86\x23\x91\x00\x88\x4e\x33\xa9\x3b\x6b\x56\x6f\x44\x95\x19\x17\x67\xdc\x25\x96\xbb\x9b\x6a\xb5\xaa\x56\x6f\x05\xf3\x34\x08\xe7\xe3\xe2\x08\x1d\xfe\x2a\x68\xb8\xc9\x68\xc8\x27\x13\x20\x3e\x2e\x13\x7e\x5e\x23\xe8\x84\x6d\x75\x89\xed\x43\x66\x93\x29\x5b\x99\xa1\xd3\x76\x8d\xc9\x69\xfe\xf2\xd2\xe1\x40\x9f\x4d\x20\xb7\x43\xcd\xd0\xf5\x67\x37\x5f\x55\x4f\x36\x86\xd3\x0b\xda\xa1\x8e\x09\x62\xaf\xd9\xde\x99\xda\x5f\x96\xbf\x2c\x85\xe8\x1c\xb6\x68\xf0\xd2\xf4\x78\x57\x36\x0a\xff\xa2\x3c\x8f\xd9\xf8\x73\xf5\xa1\x5a\xbe\x27\x17\x39\x0f\x9e\x64\x74\xdc\x40\x12\xcb\xab\x9a\x11\xc1\xa1\x7c\x63\xc6\xd1\x73\x20\x67\x50\x7f\x73\xba\x86\xc5\x13\x52\x47\x8e\x07\xb7\xb3\x3b\xd5\x90\xab\xe1\x70\x94\x5f\xd8\xd3\x33\x6f\xce\xfd\x69\xac\xdc\x92\xe3\xb5\x21\x93\x17\xcd\x0b\xd4\x7a\xae\xcc\xdc\x1a\x2a\x4c\xa9\xe4\x56\xd5\xea\xa6\x7c\x5f\xf4\xf2\x50\x02\x85\xa8\xc7\xe7\x7f\xb1\x44\x76\xfb\xe3\x72\xb9\x5c\x96\x52\xf1\x03\x9a\xc7\x60\x1d\x76\xf4\x75\x1c\x08\x38\xb7\x04\x12\x0d\xac\x09\x66\x59\xc6\x2c\x8d\x85\xd9\x1a\x9b\x8e\xdc\x2c\xcd\xbd\xa3\x51\xd9\xa1\x24\xed\x95\xac\x1a\x32\x47\xba\x1b\xc8\x79\xe5\xc3\x11\x8b\x00\x3e\xab\xbd\xe5\xe5\x28\xc3\x7b\x36\x3b\xbd\x78\x54\x2f\x54\xc3\xc7\xbf\x71\x01\x03\xa0\xe4\x21\x75\x67\x79\x61\x7b\x20\x6c\xbe\x3b\x15\xe8\x0e\xcd\x58\x1c\x71\x14\xc8\x70\x51\x82\xf2\x69\x76\x05\xd5\x13\x8f\xc8\x2d\xd1\x90\x0f\x92\xfb\xc9\x7c\x65\xd2\x49\xb6\x6f\xd2\x77\x0d\x5a\x6d\x09\x3e\xdd\x6c\x4a\xad\x15\x81\xc5\xae\xb3\xe2\x3e\xdd\x00\x27\x38\x73\x57\xaa\xe1\xd8\x6e\x5f\xd4\xb0\x55\xe6\x4d\x2c\x4c\xe5\x73\x9f\x31\xf1\xa7\xd5\x4a\x88\x0c\x6e\xe5\x78\x67\x20\x17\x7a\x34\xd8\x91\x63\x2f\xd6\x04\x83\x23\xcf\x63\x9d\x93\xd1\x46\x23\x59\x3b\xea\xc3\x1a\x82\x32\x44\xd4\xc7\xde\x28\x30\x36\x2a\x78\x65\xb6\x17\x7b\xe1\x1b\xa8\x6a\x75\x24\x13\x16\xf9\xd1\x9c\xb4\xb8\x55\xf5\x69\xfe\xde\x0e\x77\x3a\xa4\x79\x19\x4c\x49\xdb\x84\x30\x08\x48\x8f\xd2\x20\x0e\xad\xc5\x4f\x01\x77\xe3\x77\x54\xe1\x4e\x99\x54\xa4\xfd\xeb\x73\x7c\x3e\x27\x4e\x07\x65\x86\xa1\x51\xf2\x8b\xf9\x15\x95\x8e\x8e\x26\x37\xa7\x2d\xbe\xc3\x40\x7b\x1c\xeb\xd7\x69\x7c\x9d\x17\x71\x12\x75\xa6\xb8\x82\x87\x9c\x8f\x06\x54\x0b\x87\x80\x72\x89\x95\x80\x56\x3f\xc6\x96\x77\xe3\x4d\xe6\xfe\x62\x08\x6c\x0b\x68\xac\x19\x7b\x1b\x3d\x70\xed\xf5\x8c\x05\x6b\x52\x07\x65\x76\x9e\x58\xb6\xa9\x8f\x54\x89\xf5\xeb\x86\xe0\x69\x1f\xc0\xab\xce\x28\xd3\xc1\x96\x46\xd8\x2b\xad\x19\x97\x1d\x19\x5e\x82\xa8\x81\xd6\xd9\x3e\x65\xbf\xb8\xc5\x30\xe1\x5f\x18\x08\x76\x4b\xe6\x3a\x49\x5a\xc7\x30\x01\xda\xe6\x1f\xa1\x86\x0c\xd7\x54\xf4\x74\xbe\x23\x96\x3f\x04\x36\xea\xea\x69\x1f\x1e\xb3\xea\xbf\xd3\x98\xc7\x7f\x59\xd9\xb8\xc2\xca\xeb\x96\xc6\x5a\x88\x33\x8f\x2e\x16\xdb\x15\x7c\x3e\xa5\xe1\xf8\xe5\x91\x14\x1d\x35\xb0\x3e\x6e\xe2\xc1\x02\xb2\x31\xa7\x51\x32\x1d\xa0\x19\xd3\x9a\xbd\xe8\x9c\x8d\xc3\xc2\xe3\x84\xe0\x3c\xf8\xf3\x16\xcd\x44\x5e\xda\x81\x7e\x70\x5b\x46\x1f\x6c\xaf\x5e\xa8\xc9\x94\xec\xf4\xef\xd9\x80\xdf\xa1\xe5\x6d\xa5\x2a\xdb\xe8\x61\x4e\xf2\x58\x4d\x2a\x79\xbf\x99\x3d\xa1\xa1\xaa\xb1\xf4\x97\xe9\x2f\x48\xda\x7e\x56\xfe\xab\x00\x92\x51\x89\xae\xa1\x1d\x69\xcb\x9e\xce\xae\x67\xd8\xf4\xca\xf8\x23\x59\x49\x51\xc9\x50\xa2\xcf\xe6\xd7\xe7\x37\x75\x09\xc6\xf4\x3c\x91\xc0\xde\x25\xc6\x9d\xa2\xfd\xec\x1a\x26\x85\x3d\x99\x30\xfb\x4d\xfc\x6f\x00\xd3\x01\xba\x6f\xec\x0e\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
@@ -6,6 +6,7 @@ require (
 	emperror.dev/errors v0.4.2
 	emperror.dev/handler/logrus v0.1.0
 	github.com/AlecAivazis/survey/v2 v2.0.2
+	github.com/HdrHistogram/hdrhistogram-go v0.9.0
 	github.com/MakeNowJust/heredoc v0.0.0-20171113091838-e9091a26100e
 	github.com/Masterminds/sprig v2.20.0+incompatible // indirect
 	github.com/banzaicloud/istio-operator v0.0.0-20190821151858-a47cd7d9bc7a
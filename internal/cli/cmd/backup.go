@@ -0,0 +1,286 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"istio.io/operator/pkg/object"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
+	clierrors "github.com/banzaicloud/backyards-cli/internal/errors"
+	internalk8s "github.com/banzaicloud/backyards-cli/internal/k8s"
+	"github.com/banzaicloud/backyards-cli/internal/release"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+const (
+	defaultBackupFile = "backyards-backup.tar.gz"
+
+	backupMetadataFile  = "backup.json"
+	backupReleaseFile   = "release.json"
+	backupResourcesPath = "resources"
+)
+
+// backupResources are the mesh configuration resources in the order they are restored
+var backupResources = []schema.GroupVersionResource{
+	{Group: "networking.istio.io", Version: "v1alpha3", Resource: "serviceentries"},
+	{Group: "networking.istio.io", Version: "v1alpha3", Resource: "gateways"},
+	{Group: "networking.istio.io", Version: "v1alpha3", Resource: "destinationrules"},
+	{Group: "networking.istio.io", Version: "v1alpha3", Resource: "virtualservices"},
+	{Group: "networking.istio.io", Version: "v1alpha3", Resource: "sidecars"},
+	{Group: "authentication.istio.io", Version: "v1alpha1", Resource: "policies"},
+}
+
+// backupKinds are the kinds of the backup resources in the same order
+var backupKinds = []string{"ServiceEntry", "Gateway", "DestinationRule", "VirtualService", "Sidecar", "Policy"}
+
+type backupCommand struct {
+	cli cli.CLI
+}
+
+type BackupOptions struct {
+	file        string
+	releaseName string
+	namespaces  []string
+}
+
+// BackupMetadata describes the content of a backup archive
+type BackupMetadata struct {
+	CLIVersion string    `json:"cliVersion"`
+	Timestamp  time.Time `json:"timestamp"`
+	Namespaces []string  `json:"namespaces,omitempty"`
+}
+
+// BackupResource is an object stored in a backup archive
+type BackupResource struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func NewBackupCommand(cli cli.CLI) *cobra.Command {
+	c := &backupCommand{
+		cli: cli,
+	}
+	options := &BackupOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "backup [file] [flags]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Archive the mesh configuration",
+		Long: `Archive the mesh configuration.
+
+The command saves the Istio networking and security objects (VirtualService,
+DestinationRule, Gateway, ServiceEntry, Sidecar and Policy), the Istio custom
+resource and the settings of the latest Backyards release into a gzipped tar
+archive, which can be applied with 'backyards restore'. The objects installed
+by the CLI itself are left out, restore renders them again from the release.`,
+		Example: `  # Archive the mesh configuration of every namespace.
+  backyards backup mesh.tar.gz
+
+  # Archive the mesh configuration of some namespaces only.
+  backyards backup mesh.tar.gz --namespaces default,backyards-demo`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if len(args) > 0 {
+				options.file = args[0]
+			}
+
+			return c.run(options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.file, "file", "f", defaultBackupFile, "Path of the archive to create")
+	cmd.Flags().StringVar(&options.releaseName, "release-name", defaultReleaseName, "Name of the release")
+	cmd.Flags().StringSliceVar(&options.namespaces, "namespaces", options.namespaces, "Namespaces to archive the mesh configuration of (defaults to every namespace)")
+
+	return cmd
+}
+
+func (c *backupCommand) run(options *BackupOptions) error {
+	client, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	objects, err := k8s.ListObjectsOfResources(config, []schema.GroupVersionResource{
+		v1beta1.SchemeGroupVersion.WithResource("istios"),
+	}, []string{istio.IstioNamespace})
+	if err != nil {
+		return err
+	}
+
+	meshObjects, err := k8s.ListObjectsOfResources(config, backupResources, options.namespaces)
+	if err != nil {
+		return err
+	}
+	for _, obj := range meshObjects {
+		// the objects of the Backyards chart are rendered again from the release record
+		if _, ok := obj.UnstructuredObject().GetLabels()[internalk8s.CLIVersionLabel]; ok {
+			c.cli.Logger().Debugf("skipping %s managed by the CLI", obj.Hash())
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	record, err := release.NewStore(client, viper.GetString("backyards.namespace")).Latest(options.releaseName)
+	if err != nil {
+		if !clierrors.IsNotFound(err) {
+			return err
+		}
+		c.cli.Logger().Warnf("no revisions recorded for release %s, the Backyards settings are not archived", options.releaseName)
+		record = nil
+	}
+
+	err = writeBackup(options.file, BackupMetadata{
+		CLIVersion: c.cli.GetRootCommand().Version,
+		Timestamp:  time.Now().UTC(),
+		Namespaces: options.namespaces,
+	}, record, objects)
+	if err != nil {
+		return err
+	}
+
+	c.cli.Logger().Infof("mesh configuration archived to %s", options.file)
+
+	return outputBackupResources(c.cli, objects)
+}
+
+func writeBackup(file string, metadata BackupMetadata, record *release.Record, objects object.K8sObjects) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not create archive", "file", file)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	write := func(name string, content []byte) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(content)),
+			ModTime: metadata.Timestamp,
+		})
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not write archive entry", "name", name)
+		}
+		_, err = tw.Write(content)
+		return errors.WrapIfWithDetails(err, "could not write archive entry", "name", name)
+	}
+
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return errors.WrapIf(err, "could not marshal backup metadata")
+	}
+	err = write(backupMetadataFile, content)
+	if err != nil {
+		return err
+	}
+
+	if record != nil {
+		content, err = json.MarshalIndent(record, "", "  ")
+		if err != nil {
+			return errors.WrapIf(err, "could not marshal release record")
+		}
+		err = write(backupReleaseFile, content)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, obj := range objects {
+		content, err = yaml.Marshal(sanitizeBackupObject(obj.UnstructuredObject()).Object)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not marshal object", "name", obj.Hash())
+		}
+		err = write(path.Join(backupResourcesPath, obj.Kind, obj.Namespace, obj.Name+".yaml"), content)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return errors.WrapIf(err, "could not close archive")
+	}
+
+	return errors.WrapIf(gw.Close(), "could not close archive")
+}
+
+// sanitizeBackupObject removes the fields maintained by the API server
+func sanitizeBackupObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+
+	for _, field := range []string{"resourceVersion", "uid", "selfLink", "creationTimestamp", "generation", "managedFields", "ownerReferences", "finalizers"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+	if len(obj.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	return obj
+}
+
+func outputBackupResources(cli cli.CLI, objects object.K8sObjects) error {
+	resources := make([]BackupResource, len(objects))
+	for i, obj := range objects {
+		resources[i] = BackupResource{
+			Kind:      obj.Kind,
+			Namespace: obj.Namespace,
+			Name:      obj.Name,
+		}
+	}
+
+	ctx := &output.Context{
+		Out:     cli.Out(),
+		Color:   cli.Color(),
+		Format:  cli.OutputFormat(),
+		Fields:  []string{"Kind", "Namespace", "Name"},
+		Headers: []string{"Kind", "Namespace", "Name"},
+	}
+
+	err := output.Output(ctx, resources)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
@@ -17,18 +17,16 @@ package canary
 import (
 	"context"
 	"fmt"
-	"os"
-	"time"
 
 	"emperror.dev/errors"
 	"github.com/spf13/cobra"
 	"istio.io/operator/pkg/object"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	"github.com/banzaicloud/backyards-cli/cmd/backyards/static/canary_operator"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 	"github.com/banzaicloud/backyards-cli/pkg/helm"
@@ -36,12 +34,12 @@ import (
 )
 
 const (
-	istioNotFoundErrorTemplate = `Unable to install Backyards: %s
+	// DefaultNamespace is the namespace the canary operator is installed into by default
+	DefaultNamespace = "backyards-canary"
+	// DefaultPrometheusURL is the address of the Prometheus installed along with Backyards
+	DefaultPrometheusURL = "http://backyards-prometheus.backyards-system:9090/prometheus"
 
-An existing Istio installation is required. You can install it with:
-
-backyards istio install
-`
+	istioNotFoundErrorTemplate = "unable to install the canary operator: %s, an existing Istio installation is required, install it with 'backyards istio install'"
 )
 
 type installCommand struct {
@@ -51,15 +49,16 @@ type installCommand struct {
 type InstallOptions struct {
 	releaseName             string
 	canaryOperatorNamespace string
-	istioNamespace          string
-	prometheusURL           string
+	PrometheusURL           string
 
 	DumpResources bool
 }
 
 // NewInstallOptions get InstallOptions
 func NewInstallOptions() *InstallOptions {
-	return &InstallOptions{}
+	return &InstallOptions{
+		PrometheusURL: DefaultPrometheusURL,
+	}
 }
 
 // NewInstallCommand get installCommand
@@ -91,9 +90,8 @@ It can only dump the applicable resources with the '--dump-resources' option.
 	}
 
 	cmd.Flags().StringVar(&options.releaseName, "release-name", "canary-operator", "Name of the release")
-	cmd.Flags().StringVar(&options.istioNamespace, "istio-namespace", "istio-system", "Namespace of Istio sidecar injector")
-	cmd.Flags().StringVar(&options.canaryOperatorNamespace, "canary-namespace", "backyards-canary", "Namespace for the canary operator")
-	cmd.Flags().StringVar(&options.prometheusURL, "prometheus-url", "http://backyards-prometheus.backyards-system:9090/prometheus", "Prometheus URL for metrics")
+	cmd.Flags().StringVar(&options.canaryOperatorNamespace, "canary-namespace", DefaultNamespace, "Namespace for the canary operator")
+	cmd.Flags().StringVar(&options.PrometheusURL, "prometheus-url", options.PrometheusURL, "Prometheus URL for metrics")
 
 	cmd.Flags().BoolVarP(&options.DumpResources, "dump-resources", "d", options.DumpResources, "Dump resources to stdout instead of applying them")
 
@@ -101,13 +99,13 @@ It can only dump the applicable resources with the '--dump-resources' option.
 }
 
 func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
-	err := c.validate(options.istioNamespace)
+	err := c.validate(istio.IstioNamespace)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, istioNotFoundErrorTemplate, err)
+		cli.Logger().Errorf(istioNotFoundErrorTemplate, err)
 		return nil
 	}
 
-	objects, err := getCanaryOperatorObjects(options.releaseName, options.canaryOperatorNamespace, options.prometheusURL)
+	objects, err := GetCanaryOperatorObjects(options.releaseName, options.canaryOperatorNamespace, options.PrometheusURL)
 	if err != nil {
 		return err
 	}
@@ -119,17 +117,17 @@ func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
 			return err
 		}
 
-		err = k8s.ApplyResources(client, cli.LabelManager(), objects)
+		config, err := cli.GetK8sConfig()
+		if err != nil {
+			return err
+		}
+
+		err = k8s.ApplyResources(client, cli.LabelManager(), cli.Logger(), objects)
 		if err != nil {
 			return err
 		}
 
-		err = k8s.WaitForResourcesConditions(client, k8s.NamesWithGVKFromK8sObjects(objects), wait.Backoff{
-			Duration: time.Second * 5,
-			Factor:   1,
-			Jitter:   0,
-			Steps:    24,
-		}, k8s.ExistsConditionCheck, k8s.ReadyReplicasConditionCheck)
+		err = k8s.WaitForResourcesConditions(config, k8s.NamesWithGVKFromK8sObjects(objects), cli.WaitOptions(), k8s.ExistsConditionCheck, k8s.ReadyConditionCheck)
 		if err != nil {
 			return err
 		}
@@ -144,7 +142,7 @@ func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
 	return nil
 }
 
-func getCanaryOperatorObjects(releaseName, canaryOperatorNamespace, prometheusURL string) (object.K8sObjects, error) {
+func GetCanaryOperatorObjects(releaseName, canaryOperatorNamespace, prometheusURL string) (object.K8sObjects, error) {
 	var values Values
 
 	valuesYAML, err := helm.GetDefaultValues(canary_operator.Chart)
@@ -174,7 +172,7 @@ func getCanaryOperatorObjects(releaseName, canaryOperatorNamespace, prometheusUR
 		return nil, errors.WrapIf(err, "could not render helm manifest objects")
 	}
 
-	return objects, nil
+	return util.RewriteImages(objects), nil
 }
 
 func (c *installCommand) validate(istioNamespace string) error {
@@ -16,12 +16,10 @@ package canary
 
 import (
 	"fmt"
-	"time"
 
 	"emperror.dev/errors"
 	"github.com/spf13/cobra"
 	"istio.io/operator/pkg/object"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 	"github.com/banzaicloud/backyards-cli/pkg/helm"
@@ -72,7 +70,7 @@ The command can uninstall every component at once with the '--uninstall-everythi
 	}
 
 	cmd.Flags().StringVar(&options.releaseName, "release-name", "canary-operator", "Name of the release")
-	cmd.Flags().StringVar(&options.canaryOperatorNamespace, "canary-namespace", "backyards-canary", "Namespace for the canary operator")
+	cmd.Flags().StringVar(&options.canaryOperatorNamespace, "canary-namespace", DefaultNamespace, "Namespace for the canary operator")
 
 	cmd.Flags().BoolVarP(&options.DumpResources, "dump-resources", "d", options.DumpResources, "Dump resources to stdout instead of applying them")
 
@@ -80,7 +78,7 @@ The command can uninstall every component at once with the '--uninstall-everythi
 }
 
 func (c *uninstallCommand) run(cli cli.CLI, options *UninstallOptions) error {
-	objects, err := getCanaryOperatorObjects(options.releaseName, options.canaryOperatorNamespace, "")
+	objects, err := GetCanaryOperatorObjects(options.releaseName, options.canaryOperatorNamespace, "")
 	if err != nil {
 		return err
 	}
@@ -109,12 +107,12 @@ func (c *uninstallCommand) deleteResources(objects object.K8sObjects) error {
 		return err
 	}
 
-	err = k8s.DeleteResources(client, c.cli.LabelManager(), objects, k8s.WaitForResourceConditions(wait.Backoff{
-		Duration: time.Second * 5,
-		Factor:   1,
-		Jitter:   0,
-		Steps:    24,
-	}, k8s.NonExistsConditionCheck))
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	err = k8s.DeleteResources(client, c.cli.LabelManager(), c.cli.Logger(), objects, k8s.WaitForResourceConditions(config, c.cli.WaitOptions(), k8s.NonExistsConditionCheck))
 	if err != nil {
 		return errors.WrapIf(err, "could not delete k8s resources")
 	}
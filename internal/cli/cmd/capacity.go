@@ -0,0 +1,368 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"istio.io/operator/pkg/object"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	istioutil "github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+const (
+	// capacityWarningRatio is the share of the free capacity above which the requests are reported as a warning
+	capacityWarningRatio = 0.8
+
+	sidecarInjectionLabel   = "istio-injection"
+	sidecarInjectAnnotation = "sidecar.istio.io/inject"
+)
+
+// checkCapacity compares the resource requests of the workloads which are going to be created
+// with the allocatable capacity left on the schedulable nodes
+func (c *preflightCommand) checkCapacity(cl k8sclient.Client, components []ComponentObjects) (PreflightResult, error) {
+	result := PreflightResult{
+		Check:  "capacity",
+		Result: PreflightPass,
+	}
+
+	sidecars, err := newSidecarInjection(cl, components)
+	if err != nil {
+		return result, err
+	}
+
+	required := corev1.ResourceList{}
+	breakdown := make([]string, 0)
+	for _, component := range components {
+		requests, err := newWorkloadRequests(cl, component.Objects, sidecars)
+		if err != nil {
+			return result, err
+		}
+		if requests.Cpu().IsZero() && requests.Memory().IsZero() {
+			continue
+		}
+		addResourceList(required, requests)
+		breakdown = append(breakdown, fmt.Sprintf("%s %s", component.Name, formatRequests(requests)))
+	}
+
+	free, err := freeCapacity(cl)
+	if err != nil {
+		return result, err
+	}
+
+	result.Message = fmt.Sprintf("new workloads request %s of %s free", formatRequests(required), formatRequests(free))
+	if len(breakdown) > 0 {
+		result.Message += " (" + strings.Join(breakdown, ", ") + ")"
+	}
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		req := required[name]
+		available := free[name]
+		switch {
+		case req.Cmp(available) > 0:
+			result.Result = PreflightFail
+		case float64(req.MilliValue()) > capacityWarningRatio*float64(available.MilliValue()) && result.Result != PreflightFail:
+			result.Result = PreflightWarn
+		}
+	}
+
+	return result, nil
+}
+
+// newWorkloadRequests sums the resource requests of the pods of the deployments and statefulsets
+// which do not exist yet, as the pods of the existing ones are already scheduled
+func newWorkloadRequests(cl k8sclient.Client, objects object.K8sObjects, sidecars *sidecarInjection) (corev1.ResourceList, error) {
+	total := corev1.ResourceList{}
+
+	for _, obj := range objects {
+		var replicas *int32
+		var podSpec corev1.PodSpec
+		var podAnnotations map[string]string
+		var existing runtime.Object
+
+		switch obj.Kind {
+		case "Deployment":
+			var deployment appsv1.Deployment
+			err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredObject().Object, &deployment)
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "could not convert deployment", "name", obj.Name)
+			}
+			replicas, podSpec, existing = deployment.Spec.Replicas, deployment.Spec.Template.Spec, &appsv1.Deployment{}
+			podAnnotations = deployment.Spec.Template.Annotations
+		case "StatefulSet":
+			var statefulset appsv1.StatefulSet
+			err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredObject().Object, &statefulset)
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "could not convert statefulset", "name", obj.Name)
+			}
+			replicas, podSpec, existing = statefulset.Spec.Replicas, statefulset.Spec.Template.Spec, &appsv1.StatefulSet{}
+			podAnnotations = statefulset.Spec.Template.Annotations
+		default:
+			continue
+		}
+
+		err := cl.Get(context.Background(), types.NamespacedName{
+			Name:      obj.Name,
+			Namespace: obj.Namespace,
+		}, existing)
+		if err == nil {
+			continue
+		}
+		if !k8serrors.IsNotFound(err) {
+			return nil, errors.WrapIfWithDetails(err, "could not get workload", "kind", obj.Kind, "name", obj.Name)
+		}
+
+		podSpec = sidecars.inject(obj.Namespace, podAnnotations, podSpec)
+
+		count := int64(1)
+		if replicas != nil {
+			count = int64(*replicas)
+		}
+		for name, quantity := range podRequests(podSpec) {
+			q := total[name]
+			q.Add(*resource.NewMilliQuantity(quantity.MilliValue()*count, quantity.Format))
+			total[name] = q
+		}
+	}
+
+	return total, nil
+}
+
+// sidecarInjection holds the requests of the containers the sidecar injector adds to the pods
+// of the namespaces with injection enabled
+type sidecarInjection struct {
+	namespaces    map[string]bool
+	policyEnabled bool
+	proxy         corev1.ResourceList
+	init          corev1.ResourceList
+}
+
+// newSidecarInjection returns the injection settings of the Istio resource which is going to be installed,
+// or of the one in the cluster, nil if there is none
+func newSidecarInjection(cl k8sclient.Client, components []ComponentObjects) (*sidecarInjection, error) {
+	var istio *v1beta1.Istio
+	for _, component := range components {
+		for _, obj := range component.Objects {
+			if obj.Kind != "Istio" {
+				continue
+			}
+			istio = &v1beta1.Istio{}
+			err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredObject().Object, istio)
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "could not convert Istio resource", "name", obj.Name)
+			}
+		}
+	}
+
+	if istio == nil {
+		var istios v1beta1.IstioList
+		err := cl.List(context.Background(), &istios)
+		if k8smeta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.WrapIf(err, "could not list Istio resources")
+		}
+		if len(istios.Items) == 0 {
+			return nil, nil
+		}
+		istio = &istios.Items[0]
+	}
+
+	v1beta1.SetDefaults(istio)
+	if !istioutil.PointerToBool(istio.Spec.SidecarInjector.Enabled) {
+		return nil, nil
+	}
+
+	injection := &sidecarInjection{
+		namespaces:    make(map[string]bool),
+		policyEnabled: istioutil.PointerToBool(istio.Spec.SidecarInjector.AutoInjectionPolicyEnabled),
+	}
+	if istio.Spec.Proxy.Resources != nil {
+		injection.proxy = istio.Spec.Proxy.Resources.Requests
+	}
+	// the traffic is redirected by the CNI plugin instead of an init container if it is enabled
+	if istio.Spec.SidecarInjector.Init.Resources != nil && !istioutil.PointerToBool(istio.Spec.SidecarInjector.InitCNIConfiguration.Enabled) {
+		injection.init = istio.Spec.SidecarInjector.Init.Resources.Requests
+	}
+
+	var namespaces corev1.NamespaceList
+	err := cl.List(context.Background(), &namespaces, client.MatchingLabels(map[string]string{sidecarInjectionLabel: "enabled"}))
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not list namespaces")
+	}
+	for _, ns := range namespaces.Items {
+		injection.namespaces[ns.Name] = true
+	}
+	for _, namespace := range istio.Spec.AutoInjectionNamespaces {
+		injection.namespaces[namespace] = true
+	}
+	for _, component := range components {
+		for _, obj := range component.Objects {
+			if obj.Kind == "Namespace" {
+				injection.namespaces[obj.Name] = obj.UnstructuredObject().GetLabels()[sidecarInjectionLabel] == "enabled"
+			}
+		}
+	}
+
+	return injection, nil
+}
+
+// inject returns the pod spec with the sidecar and init containers added if the pod is going to be injected
+func (s *sidecarInjection) inject(namespace string, annotations map[string]string, spec corev1.PodSpec) corev1.PodSpec {
+	if s == nil || !s.namespaces[namespace] {
+		return spec
+	}
+
+	switch annotations[sidecarInjectAnnotation] {
+	case "false":
+		return spec
+	case "true":
+	default:
+		if !s.policyEnabled {
+			return spec
+		}
+	}
+
+	injected := *spec.DeepCopy()
+	injected.Containers = append(injected.Containers, corev1.Container{
+		Name:      "istio-proxy",
+		Resources: corev1.ResourceRequirements{Requests: s.proxy},
+	})
+	if s.init != nil {
+		injected.InitContainers = append(injected.InitContainers, corev1.Container{
+			Name:      "istio-init",
+			Resources: corev1.ResourceRequirements{Requests: s.init},
+		})
+	}
+
+	return injected
+}
+
+// freeCapacity returns the allocatable resources of the schedulable nodes which are not requested by running pods
+func freeCapacity(cl k8sclient.Client) (corev1.ResourceList, error) {
+	var nodes corev1.NodeList
+	err := cl.List(context.Background(), &nodes)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not list nodes")
+	}
+
+	var pods corev1.PodList
+	err = cl.List(context.Background(), &pods)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not list pods")
+	}
+
+	requested := make(map[string]corev1.ResourceList)
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if requested[pod.Spec.NodeName] == nil {
+			requested[pod.Spec.NodeName] = corev1.ResourceList{}
+		}
+		addResourceList(requested[pod.Spec.NodeName], podRequests(pod.Spec))
+	}
+
+	free := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("0"),
+		corev1.ResourceMemory: resource.MustParse("0"),
+	}
+	for _, node := range nodes.Items {
+		if !nodeSchedulable(node) {
+			continue
+		}
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			available := node.Status.Allocatable[name].DeepCopy()
+			available.Sub(requested[node.Name][name])
+			if available.Sign() > 0 {
+				q := free[name]
+				q.Add(available)
+				free[name] = q
+			}
+		}
+	}
+
+	return free, nil
+}
+
+// nodeSchedulable tells whether new pods without tolerations can be scheduled onto the node
+func nodeSchedulable(node corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
+			return false
+		}
+	}
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// podRequests returns the effective resource requests of a pod, the larger of the sum of
+// the requests of its containers and of the requests of any init container
+func podRequests(spec corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+	}
+
+	for _, container := range spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if q, ok := requests[name]; !ok || quantity.Cmp(q) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+
+	return requests
+}
+
+func addResourceList(list, add corev1.ResourceList) {
+	for name, quantity := range add {
+		q, ok := list[name]
+		if !ok {
+			list[name] = quantity.DeepCopy()
+			continue
+		}
+		q.Add(quantity)
+		list[name] = q
+	}
+}
+
+func formatRequests(list corev1.ResourceList) string {
+	return fmt.Sprintf("%dm CPU/%dMi memory", list.Cpu().MilliValue(), list.Memory().Value()/(1<<20))
+}
@@ -21,10 +21,14 @@ import (
 )
 
 const (
-	CertManagerNamespace   = "cert-manager"
+	DefaultNamespace       = "cert-manager"
 	certManagerReleaseName = "cert-manager"
 )
 
+var (
+	CertManagerNamespace string
+)
+
 func NewRootCmd(cli cli.CLI) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cert-manager",
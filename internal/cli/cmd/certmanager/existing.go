@@ -0,0 +1,153 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
+	internalk8s "github.com/banzaicloud/backyards-cli/internal/k8s"
+)
+
+const (
+	// requiredAPIGroup and requiredAPIVersion are the API of the resources created by the Backyards components
+	requiredAPIGroup   = "certmanager.k8s.io"
+	requiredAPIVersion = "v1alpha1"
+
+	// newAPIGroup is the API group of cert-manager v0.11 and later
+	newAPIGroup = "cert-manager.io"
+)
+
+// requiredResources are the cert-manager resources created by the Backyards components
+var requiredResources = []string{"certificates", "issuers"}
+
+// Installation is a cert-manager controller found in the cluster
+type Installation struct {
+	Namespace string
+	Name      string
+	Version   string
+	// Managed is true if it was installed by the CLI
+	Managed bool
+}
+
+func (i *Installation) String() string {
+	return fmt.Sprintf("cert-manager %s in namespace %s", i.Version, i.Namespace)
+}
+
+// DetectInstallation returns the cert-manager controller of the cluster, or nil if there is none
+func DetectInstallation(cl client.Client) (*Installation, error) {
+	var deployments appsv1.DeploymentList
+	err := cl.List(context.Background(), &deployments, client.MatchingLabels(util.CertManagerPodLabels))
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not list deployments")
+	}
+	if len(deployments.Items) == 0 {
+		return nil, nil
+	}
+
+	// the one in the configured namespace is preferred if there are several
+	deployment := deployments.Items[0]
+	for _, d := range deployments.Items {
+		if d.Namespace == CertManagerNamespace {
+			deployment = d
+			break
+		}
+	}
+
+	installation := &Installation{
+		Namespace: deployment.Namespace,
+		Name:      deployment.Name,
+		Version:   "unknown version",
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if i := strings.LastIndex(container.Image, ":"); i >= 0 && !strings.Contains(container.Image[i:], "/") {
+			installation.Version = container.Image[i+1:]
+			break
+		}
+	}
+
+	var ns corev1.Namespace
+	err = cl.Get(context.Background(), types.NamespacedName{Name: deployment.Namespace}, &ns)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not get namespace", "namespace", deployment.Namespace)
+	}
+	_, installation.Managed = ns.Labels[internalk8s.CLIVersionLabel]
+
+	return installation, nil
+}
+
+// CheckCompatibility checks that the CRDs of the cluster serve the cert-manager API used by the Backyards components
+func CheckCompatibility(cl client.Client, installation *Installation) error {
+	missing := make([]string, 0)
+	for _, resource := range requiredResources {
+		var crd apiextensionsv1beta1.CustomResourceDefinition
+		err := cl.Get(context.Background(), types.NamespacedName{Name: resource + "." + requiredAPIGroup}, &crd)
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, resource)
+			continue
+		}
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not get custom resource definition", "name", resource+"."+requiredAPIGroup)
+		}
+		if !servesVersion(crd, requiredAPIVersion) {
+			missing = append(missing, resource)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var crd apiextensionsv1beta1.CustomResourceDefinition
+	err := cl.Get(context.Background(), types.NamespacedName{Name: "certificates." + newAPIGroup}, &crd)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.WrapIfWithDetails(err, "could not get custom resource definition", "name", "certificates."+newAPIGroup)
+	}
+	if err == nil {
+		return errors.Errorf("%s is not compatible with Backyards: it serves the %s API group introduced in cert-manager v0.11, "+
+			"while Backyards requires %s/%s of cert-manager v0.10 or earlier; "+
+			"install Backyards without cert-manager dependent features or replace the existing cert-manager",
+			installation, newAPIGroup, requiredAPIGroup, requiredAPIVersion)
+	}
+
+	return errors.Errorf("%s is not compatible with Backyards: the %s resources are not served in %s/%s, "+
+		"which requires cert-manager v0.10 or a compatible earlier version; "+
+		"install Backyards without cert-manager dependent features or replace the existing cert-manager",
+		installation, strings.Join(missing, ", "), requiredAPIGroup, requiredAPIVersion)
+}
+
+func servesVersion(crd apiextensionsv1beta1.CustomResourceDefinition, version string) bool {
+	if len(crd.Spec.Versions) == 0 {
+		return crd.Spec.Version == version
+	}
+
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version && v.Served {
+			return true
+		}
+	}
+
+	return false
+}
@@ -18,8 +18,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"os"
-	"time"
 
 	"emperror.dev/errors"
 	"github.com/MakeNowJust/heredoc"
@@ -28,13 +26,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	internalk8s "github.com/banzaicloud/backyards-cli/internal/k8s"
 
 	"github.com/banzaicloud/backyards-cli/cmd/backyards/static/certmanager"
 	"github.com/banzaicloud/backyards-cli/cmd/backyards/static/certmanagercainjector"
 	"github.com/banzaicloud/backyards-cli/cmd/backyards/static/certmanagercrds"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 	"github.com/banzaicloud/backyards-cli/pkg/helm"
 	"github.com/banzaicloud/backyards-cli/pkg/k8s"
@@ -64,8 +62,11 @@ func NewInstallCommand(cli cli.CLI, options *InstallOptions) *cobra.Command {
 		Long: `Installs cert-manager.
 
 The command automatically applies the resources.
-It can only dump the applicable resources with the '--dump-resources' option.`,
-		Example: `  # Install to the cert-manager namespace. This command will fail if cert-manager is already installed from a different source.
+It can only dump the applicable resources with the '--dump-resources' option.
+
+An existing cert-manager installed by other means is reused if it serves the API
+version required by Backyards, otherwise the command fails with the version mismatch.`,
+		Example: `  # Install to the cert-manager namespace, or reuse a compatible cert-manager which is already installed.
   backyards cert-manager install
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -82,13 +83,23 @@ It can only dump the applicable resources with the '--dump-resources' option.`,
 }
 
 func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
+	if !options.DumpResources {
+		reused, err := c.reuseExisting()
+		if err != nil {
+			return err
+		}
+		if reused {
+			return nil
+		}
+	}
+
 	err := c.validate(CertManagerNamespace)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "cert-manager validation failed: %s", err)
+		cli.Logger().Errorf("cert-manager validation failed: %s", err)
 		return nil
 	}
 
-	objects, err := getCertManagerObjects(CertManagerNamespace)
+	objects, err := GetCertManagerObjects(CertManagerNamespace)
 	if err != nil {
 		return err
 	}
@@ -100,17 +111,17 @@ func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
 			return err
 		}
 
-		err = k8s.ApplyResources(client, cli.LabelManager(), objects)
+		config, err := cli.GetK8sConfig()
 		if err != nil {
 			return err
 		}
 
-		err = k8s.WaitForResourcesConditions(client, k8s.NamesWithGVKFromK8sObjects(objects), wait.Backoff{
-			Duration: time.Second * 5,
-			Factor:   1,
-			Jitter:   0,
-			Steps:    24,
-		}, k8s.ExistsConditionCheck, k8s.ReadyReplicasConditionCheck)
+		err = k8s.ApplyResources(client, cli.LabelManager(), cli.Logger(), objects)
+		if err != nil {
+			return err
+		}
+
+		err = k8s.WaitForResourcesConditions(config, k8s.NamesWithGVKFromK8sObjects(objects), cli.WaitOptions(), k8s.ExistsConditionCheck, k8s.ReadyConditionCheck, k8s.WebhookEndpointsConditionCheck(client))
 		if err != nil {
 			return err
 		}
@@ -125,6 +136,31 @@ func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
 	return nil
 }
 
+// reuseExisting returns true if a compatible cert-manager installed by other means is found
+func (c *installCommand) reuseExisting() (bool, error) {
+	client, err := c.cli.GetK8sClient()
+	if err != nil {
+		return false, err
+	}
+
+	installation, err := DetectInstallation(client)
+	if err != nil {
+		return false, errors.WrapIf(err, "could not detect existing cert-manager")
+	}
+	if installation == nil || installation.Managed {
+		return false, nil
+	}
+
+	err = CheckCompatibility(client, installation)
+	if err != nil {
+		return false, err
+	}
+
+	c.cli.Logger().Infof("reusing existing %s", installation)
+
+	return true, nil
+}
+
 // validate detects only that the cert-manager namespace exists or not for better UX.
 // Conflicting CRDs and all other resources will be detected by the k8s resource applier anyways.
 func (c *installCommand) validate(namespace string) error {
@@ -181,7 +217,7 @@ func getCertManagerCRDs() (object.K8sObjects, error) {
 	return object.ParseK8sObjectsFromYAMLManifest(buf.String())
 }
 
-func getCertManagerObjects(namespace string) (object.K8sObjects, error) {
+func GetCertManagerObjects(namespace string) (object.K8sObjects, error) {
 	valuesYAML, err := helm.GetDefaultValues(certmanager.Chart)
 	if err != nil {
 		return nil, errors.WrapIf(err, "could not get helm default values")
@@ -222,5 +258,5 @@ func getCertManagerObjects(namespace string) (object.K8sObjects, error) {
 		return nil, errors.WrapIf(err, "could not render cert-manager crd objects")
 	}
 
-	return append(crdObjects, append(namespaceObj, append(cainjectorObjects, objects...)...)...), nil
+	return util.RewriteImages(append(crdObjects, append(namespaceObj, append(cainjectorObjects, objects...)...)...)), nil
 }
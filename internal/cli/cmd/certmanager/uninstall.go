@@ -17,7 +17,6 @@ package certmanager
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"emperror.dev/errors"
 	"github.com/sirupsen/logrus"
@@ -26,7 +25,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	internalk8s "github.com/banzaicloud/backyards-cli/internal/k8s"
 
@@ -93,7 +91,7 @@ func (c *uninstallCommand) run(cli cli.CLI, options *UninstallOptions) error {
 		return err
 	}
 
-	objects, err := getCertManagerObjects(CertManagerNamespace)
+	objects, err := GetCertManagerObjects(CertManagerNamespace)
 	if err != nil {
 		return err
 	}
@@ -122,6 +120,7 @@ func (c *uninstallCommand) validate(namespace string, opts *UninstallOptions) er
 	if err != nil {
 		return err
 	}
+
 	targetNamespace := &corev1.Namespace{}
 	err = client.Get(context.Background(), types.NamespacedName{Name: namespace}, targetNamespace)
 	if err != nil {
@@ -147,12 +146,12 @@ func (c *uninstallCommand) deleteResources(objects object.K8sObjects) error {
 		return err
 	}
 
-	err = k8s.DeleteResources(client, c.cli.LabelManager(), objects, k8s.WaitForResourceConditions(wait.Backoff{
-		Duration: time.Second * 5,
-		Factor:   1,
-		Jitter:   0,
-		Steps:    24,
-	}, k8s.NonExistsConditionCheck))
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	err = k8s.DeleteResources(client, c.cli.LabelManager(), c.cli.Logger(), objects, k8s.WaitForResourceConditions(config, c.cli.WaitOptions(), k8s.NonExistsConditionCheck))
 	if err != nil {
 		return errors.WrapIf(err, "could not delete k8s resources")
 	}
@@ -18,6 +18,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
 	"github.com/banzaicloud/backyards-cli/pkg/helm"
 )
 
@@ -28,6 +29,17 @@ const (
 	impersonation AuthMethod = "impersonation"
 )
 
+type IngressTLS struct {
+	SecretName string   `json:"secretName"`
+	Hosts      []string `json:"hosts"`
+}
+
+type IngressIssuer struct {
+	Type  string `json:"type,omitempty"`
+	Email string `json:"email,omitempty"`
+	Class string `json:"class,omitempty"`
+}
+
 type Values struct {
 	NameOverride         string                      `json:"nameOverride,omitempty"`
 	FullnameOverride     string                      `json:"fullnameOverride,omitempty"`
@@ -42,12 +54,11 @@ type Values struct {
 			Application string `json:"application"`
 			Web         string `json:"web"`
 		} `json:"paths"`
-		BasePath string   `json:"basePath"`
-		Hosts    []string `json:"hosts"`
-		TLS      []struct {
-			SecretName string   `json:"secretName"`
-			Hosts      []string `json:"hosts"`
-		} `json:"tls"`
+		BasePath string       `json:"basePath"`
+		Hosts    []string     `json:"hosts"`
+		TLS      []IngressTLS `json:"tls"`
+		// Issuer is only used by the CLI to create the certificate of the ingress
+		Issuer IngressIssuer `json:"issuer,omitempty"`
 	} `json:"ingress"`
 
 	Autoscaling struct {
@@ -89,7 +100,10 @@ type Values struct {
 		Image       helm.Image                  `json:"image"`
 		Resources   corev1.ResourceRequirements `json:"resources,omitempty"`
 		ExternalURL string                      `json:"externalUrl"`
-		Config      struct {
+		// Host and URL point Backyards to an external Prometheus if the bundled one is disabled
+		Host   string `json:"host,omitempty"`
+		URL    string `json:"url,omitempty"`
+		Config struct {
 			Global struct {
 				ScrapeInterval     string `json:"scrapeInterval"`
 				ScrapeTimeout      string `json:"scrapeTimeout"`
@@ -119,7 +133,9 @@ type Values struct {
 		Enabled     bool   `json:"enabled"`
 		ExternalURL string `json:"externalUrl"`
 		Provider    string `json:"provider"`
-		Jaeger      struct {
+		// Address is only used by the CLI to point the Istio proxies to an external collector
+		Address string `json:"address,omitempty"`
+		Jaeger  struct {
 			Image     helm.Image                  `json:"image"`
 			Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 			Memory    struct {
@@ -128,7 +144,9 @@ type Values struct {
 			SpanStorageType  string `json:"spanStorageType"`
 			Persist          bool   `json:"persist"`
 			StorageClassName string `json:"storageClassName"`
+			StorageSize      string `json:"storageSize"`
 			AccessMode       string `json:"accessMode"`
+			Retention        string `json:"retention"`
 		} `json:"jaeger"`
 		Service struct {
 			Annotations  map[string]string `json:"annotations"`
@@ -179,6 +197,8 @@ type Values struct {
 
 func (values *Values) SetDefaults(releaseName, istioNamespace string) {
 	values.NameOverride = releaseName
+	values.Istio.Namespace = istioNamespace
+	values.Istio.CRName = istio.IstioCRName
 	values.UseNamespaceResource = true
 	values.Resources = corev1.ResourceRequirements{
 		Requests: corev1.ResourceList{
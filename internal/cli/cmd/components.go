@@ -0,0 +1,87 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"istio.io/operator/pkg/object"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/canary"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/certmanager"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/demoapp"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
+)
+
+const (
+	ComponentBackyards   = "backyards"
+	ComponentIstio       = "istio"
+	ComponentCertManager = "cert-manager"
+	ComponentCanary      = "canary"
+	ComponentDemoapp     = "demoapp"
+)
+
+// ComponentObjects holds the rendered manifests of a component
+type ComponentObjects struct {
+	Name    string
+	Objects object.K8sObjects
+}
+
+// GetComponentObjects renders the manifests of every component the CLI can install with their default values
+func GetComponentObjects(releaseName, canaryNamespace string) ([]ComponentObjects, error) {
+	values, err := getValues(releaseName, istio.IstioNamespace, nil)
+	if err != nil {
+		return nil, err
+	}
+	backyardsObjects, err := getBackyardsObjects(values)
+	if err != nil {
+		return nil, err
+	}
+
+	istioObjects, err := istio.GetIstioOperatorObjects("istio-operator")
+	if err != nil {
+		return nil, err
+	}
+	istioCR, err := istio.GetIstioCR("")
+	if err != nil {
+		return nil, err
+	}
+
+	certManagerObjects, err := certmanager.GetCertManagerObjects(certmanager.CertManagerNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	canaryObjects, err := canary.GetCanaryOperatorObjects("canary-operator", canaryNamespace, canary.DefaultPrometheusURL)
+	if err != nil {
+		return nil, err
+	}
+
+	demoObjects, err := demoapp.GetBackyardsDemoObjects(demoapp.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	return []ComponentObjects{
+		{Name: ComponentBackyards, Objects: backyardsObjects},
+		{Name: ComponentIstio, Objects: append(istioObjects, istioCR)},
+		{Name: ComponentCertManager, Objects: certManagerObjects},
+		{Name: ComponentCanary, Objects: canaryObjects},
+		{Name: ComponentDemoapp, Objects: demoObjects},
+	}, nil
+}
+
+// GetDefaultComponentObjects renders the manifests of every component into their default namespaces
+func GetDefaultComponentObjects() ([]ComponentObjects, error) {
+	return GetComponentObjects(defaultReleaseName, defaultCanaryNamespace)
+}
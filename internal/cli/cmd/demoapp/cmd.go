@@ -33,6 +33,7 @@ func NewRootCmd(cli cli.CLI) *cobra.Command {
 		NewInstallCommand(cli, NewInstallOptions()),
 		NewUninstallCommand(cli, NewUninstallOptions()),
 		NewLoadCommand(cli, NewLoadOptions()),
+		NewScenarioCommand(cli),
 	)
 
 	cmd.PersistentFlags().StringVar(&backyardsDemoNamespace, "demo-namespace", backyardsDemoNamespace, "Namespace for demo application")
@@ -18,17 +18,19 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"time"
 
 	"emperror.dev/errors"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"istio.io/operator/pkg/object"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	"github.com/banzaicloud/backyards-cli/cmd/backyards/static/backyards_demo"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 	"github.com/banzaicloud/backyards-cli/pkg/helm"
@@ -36,6 +38,8 @@ import (
 )
 
 const (
+	sidecarInjectionLabel = "istio-injection"
+
 	istioNotFoundErrorTemplate = `Unable to install Backyards: %s
 
 An existing Istio installation is required. You can install it with:
@@ -49,8 +53,7 @@ type installCommand struct {
 }
 
 type InstallOptions struct {
-	namespace      string
-	istioNamespace string
+	Namespace string
 
 	DumpResources bool
 }
@@ -75,35 +78,33 @@ It can only dump the applicable resources with the '--dump-resources' option.`,
 		Example: `  # Default install.
   backyards demoapp install
 
-  # Install Backyards into a non-default namespace.
-  backyards demoapp install -n backyards-system`,
+  # Install demo application into a non-default namespace.
+  backyards demoapp install --demo-namespace my-demo`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceErrors = true
 			cmd.SilenceUsage = true
 
-			if options.namespace == "" {
-				options.namespace = backyardsDemoNamespace
+			if options.Namespace == "" {
+				options.Namespace = backyardsDemoNamespace
 			}
 
 			return c.run(cli, options)
 		},
 	}
 
-	cmd.Flags().StringVar(&options.istioNamespace, "istio-namespace", "istio-system", "Namespace of Istio sidecar injector")
-
 	cmd.Flags().BoolVarP(&options.DumpResources, "dump-resources", "d", options.DumpResources, "Dump resources to stdout instead of applying them")
 
 	return cmd
 }
 
 func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
-	err := c.validate(options.istioNamespace)
+	err := c.validate(istio.IstioNamespace)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, istioNotFoundErrorTemplate, err)
 		return nil
 	}
 
-	objects, err := getBackyardsDemoObjects(options.namespace)
+	objects, err := GetBackyardsDemoObjects(options.Namespace)
 	if err != nil {
 		return err
 	}
@@ -115,17 +116,22 @@ func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
 			return err
 		}
 
-		err = k8s.ApplyResources(client, cli.LabelManager(), objects)
+		config, err := cli.GetK8sConfig()
 		if err != nil {
 			return err
 		}
 
-		err = k8s.WaitForResourcesConditions(client, k8s.NamesWithGVKFromK8sObjects(objects), wait.Backoff{
-			Duration: time.Second * 5,
-			Factor:   1,
-			Jitter:   0,
-			Steps:    24,
-		}, k8s.ExistsConditionCheck, k8s.ReadyReplicasConditionCheck)
+		err = ensureSidecarInjection(client, cli.Logger(), options.Namespace)
+		if err != nil {
+			return err
+		}
+
+		err = k8s.ApplyResources(client, cli.LabelManager(), cli.Logger(), objects)
+		if err != nil {
+			return err
+		}
+
+		err = k8s.WaitForResourcesConditions(config, k8s.NamesWithGVKFromK8sObjects(objects), cli.WaitOptions(), k8s.ExistsConditionCheck, k8s.ReadyConditionCheck)
 		if err != nil {
 			return err
 		}
@@ -140,7 +146,7 @@ func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
 	return nil
 }
 
-func getBackyardsDemoObjects(namespace string) (object.K8sObjects, error) {
+func GetBackyardsDemoObjects(namespace string) (object.K8sObjects, error) {
 	var values Values
 
 	valuesYAML, err := helm.GetDefaultValues(backyards_demo.Chart)
@@ -170,7 +176,36 @@ func getBackyardsDemoObjects(namespace string) (object.K8sObjects, error) {
 		return nil, errors.WrapIf(err, "could not render helm manifest objects")
 	}
 
-	return objects, nil
+	return util.RewriteImages(objects), nil
+}
+
+// ensureSidecarInjection enables sidecar injection in the namespace if it already exists, as an existing
+// namespace which was not created by the CLI is not updated along with the other resources
+func ensureSidecarInjection(cl client.Client, logger log.FieldLogger, namespace string) error {
+	var ns v1.Namespace
+	err := cl.Get(context.Background(), types.NamespacedName{Name: namespace}, &ns)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not get namespace", "namespace", namespace)
+	}
+
+	if ns.Labels[sidecarInjectionLabel] == "enabled" {
+		return nil
+	}
+
+	if ns.Labels == nil {
+		ns.Labels = make(map[string]string)
+	}
+	ns.Labels[sidecarInjectionLabel] = "enabled"
+	err = cl.Update(context.Background(), &ns)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not enable sidecar injection", "namespace", namespace)
+	}
+	logger.Infof("sidecar injection enabled in namespace %s", namespace)
+
+	return nil
 }
 
 func (c *installCommand) validate(istioNamespace string) error {
@@ -20,7 +20,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
-	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/routing/common"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/load"
 
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 	"github.com/banzaicloud/backyards-cli/pkg/graphql"
@@ -34,8 +34,7 @@ type LoadOptions struct {
 	Nowait    bool
 	Frequency int
 	Duration  int
-
-	namespace string
+	Namespace string
 }
 
 func NewLoadOptions() *LoadOptions {
@@ -61,8 +60,8 @@ func NewLoadCommand(cli cli.CLI, options *LoadOptions) *cobra.Command {
 			cmd.SilenceErrors = true
 			cmd.SilenceUsage = true
 
-			if options.namespace == "" {
-				options.namespace = backyardsDemoNamespace
+			if options.Namespace == "" {
+				options.Namespace = backyardsDemoNamespace
 			}
 
 			return c.run(cli, options)
@@ -86,14 +85,9 @@ func (c *loadCommand) run(cli cli.CLI, options *LoadOptions) error {
 	}).Info("Sending load to demo application")
 	go func() {
 		defer wg.Done()
-		client, err := common.GetGraphQLClient(cli)
-		if err != nil {
-			log.Error(err)
-			return
-		}
-		defer client.Close()
-		response, err = client.GenerateLoad(graphql.GenerateLoadRequest{
-			Namespace: options.namespace,
+		var err error
+		response, err = load.GenerateLoad(cli, graphql.GenerateLoadRequest{
+			Namespace: options.Namespace,
 			Service:   "frontpage",
 			Port:      8080,
 			Endpoint:  "/",
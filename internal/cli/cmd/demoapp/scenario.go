@@ -0,0 +1,691 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package demoapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis/istio/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/load"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/graphql"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+)
+
+// scenario is a guided series of changes to the routing rules of the demo application while it is under load
+type scenario struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	steps []scenarioStep
+}
+
+// scenarioStep is a state of the routing rules of the demo application, the services which are not
+// changed by a step have their original rules
+type scenarioStep struct {
+	name        string
+	description string
+	rps         int
+
+	// weights of the subsets of the services
+	routes map[string]map[string]int
+	faults map[string]*v1alpha3.HTTPFaultInjection
+	// connection pool and outlier detection settings of the services
+	circuitBreakers map[string]*v1alpha3.TrafficPolicy
+}
+
+// serviceRules are the routing rules of a service which are changed by the scenarios, nil if they do not exist
+type serviceRules struct {
+	virtualService  *unstructured.Unstructured
+	destinationRule *unstructured.Unstructured
+	port            uint32
+}
+
+var (
+	virtualServiceGVK  = v1alpha3.SchemeGroupVersion.WithKind("VirtualService")
+	destinationRuleGVK = v1alpha3.SchemeGroupVersion.WithKind("DestinationRule")
+)
+
+var scenarios = []scenario{
+	{
+		Name:        "canary-movies-v3",
+		Description: "Canary release of movies v3 while 5% of the requests to movies fail, then a rollback",
+		steps: []scenarioStep{
+			{
+				name:        "baseline",
+				description: "All the traffic of movies goes to v1",
+				rps:         10,
+				routes: map[string]map[string]int{
+					"movies": {"v1": 100},
+				},
+			},
+			{
+				name:        "canary",
+				description: "10% of the traffic of movies is shifted to v3, and 5% of the requests to movies fail with 503",
+				rps:         10,
+				routes: map[string]map[string]int{
+					"movies": {"v1": 90, "v3": 10},
+				},
+				faults: map[string]*v1alpha3.HTTPFaultInjection{
+					"movies": {Abort: &v1alpha3.InjectAbort{Percent: 5, HTTPStatus: 503}},
+				},
+			},
+			{
+				name:        "rollback",
+				description: "The canary is rolled back, all the traffic of movies goes to v1 again",
+				rps:         10,
+				routes: map[string]map[string]int{
+					"movies": {"v1": 100},
+				},
+			},
+		},
+	},
+	{
+		Name:        "payments-latency-spike",
+		Description: "Half of the requests to payments are delayed by 3 seconds for a while",
+		steps: []scenarioStep{
+			{
+				name:        "baseline",
+				description: "The original routing rules of the demo application",
+				rps:         10,
+			},
+			{
+				name:        "spike",
+				description: "Half of the requests to payments are delayed by 3 seconds, watch the latency of its callers on the dashboard",
+				rps:         10,
+				faults: map[string]*v1alpha3.HTTPFaultInjection{
+					"payments": {Delay: &v1alpha3.InjectDelay{Percent: 50, FixedDelay: "3s"}},
+				},
+			},
+			{
+				name:        "recovery",
+				description: "The delay is removed and the latency of payments goes back to normal",
+				rps:         10,
+			},
+		},
+	},
+	{
+		Name:        "circuit-breaker-bookings",
+		Description: "Circuit breaker on bookings which trips under increased load",
+		steps: []scenarioStep{
+			{
+				name:        "baseline",
+				description: "The original routing rules of the demo application",
+				rps:         10,
+			},
+			{
+				name:        "circuit-breaker",
+				description: "Bookings accepts a single connection and a single pending request, so the excess requests of the increased load are rejected with 503",
+				rps:         50,
+				circuitBreakers: map[string]*v1alpha3.TrafficPolicy{
+					"bookings": {
+						ConnectionPool: &v1alpha3.ConnectionPoolSettings{
+							TCP: &v1alpha3.TCPSettings{
+								MaxConnections: 1,
+							},
+							HTTP: &v1alpha3.HTTPSettings{
+								HTTP1MaxPendingRequests:  1,
+								MaxRequestsPerConnection: 1,
+							},
+						},
+						OutlierDetection: &v1alpha3.OutlierDetection{
+							ConsecutiveErrors:  1,
+							Interval:           "1s",
+							BaseEjectionTime:   "3m",
+							MaxEjectionPercent: 100,
+						},
+					},
+				},
+			},
+			{
+				name:        "recovery",
+				description: "The circuit breaker is removed and the load goes back to normal",
+				rps:         10,
+			},
+		},
+	},
+}
+
+// StepList is used by the table output
+func (s scenario) StepList() string {
+	names := make([]string, len(s.steps))
+	for i, step := range s.steps {
+		names[i] = step.name
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// services returns the services whose routing rules are changed by the scenario
+func (s scenario) services() []string {
+	set := make(map[string]bool)
+	for _, step := range s.steps {
+		for service := range step.routes {
+			set[service] = true
+		}
+		for service := range step.faults {
+			set[service] = true
+		}
+		for service := range step.circuitBreakers {
+			set[service] = true
+		}
+	}
+
+	services := make([]string, 0, len(set))
+	for service := range set {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	return services
+}
+
+func getScenario(name string) (scenario, error) {
+	for _, s := range scenarios {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+
+	return scenario{}, errors.NewWithDetails("unknown scenario", "scenario", name, "supported", scenarioNames())
+}
+
+func scenarioNames() []string {
+	names := make([]string, len(scenarios))
+	for i, s := range scenarios {
+		names[i] = s.Name
+	}
+
+	return names
+}
+
+type scenarioRunCommand struct {
+	cli cli.CLI
+}
+
+type ScenarioRunOptions struct {
+	duration int
+
+	Namespace string
+}
+
+func NewScenarioRunOptions() *ScenarioRunOptions {
+	return &ScenarioRunOptions{
+		duration: 30,
+	}
+}
+
+func NewScenarioCommand(cli cli.CLI) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scenario",
+		Short: "Run guided scenarios on the demo application",
+	}
+
+	cmd.AddCommand(
+		newScenarioListCommand(cli),
+		NewScenarioRunCommand(cli, NewScenarioRunOptions()),
+	)
+
+	return cmd
+}
+
+func newScenarioListCommand(cli cli.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Args:  cobra.NoArgs,
+		Short: "List the scenarios",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			ctx := &output.Context{
+				Out:     cli.Out(),
+				Color:   cli.Color(),
+				Format:  cli.OutputFormat(),
+				Fields:  []string{"Name", "Description", "StepList"},
+				Headers: []string{"Name", "Description", "Steps"},
+			}
+
+			err := output.Output(ctx, scenarios)
+			if err != nil {
+				return errors.WrapIf(err, "could not produce output")
+			}
+
+			return nil
+		},
+	}
+}
+
+func NewScenarioRunCommand(cli cli.CLI, options *ScenarioRunOptions) *cobra.Command {
+	c := &scenarioRunCommand{
+		cli: cli,
+	}
+
+	cmd := &cobra.Command{
+		Use:       "run [name]",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: scenarioNames(),
+		Short:     "Run a scenario on the demo application",
+		Long: `Run a scenario on the demo application.
+
+Each step of the scenario changes the traffic shifting, fault injection or
+circuit breaker rules of some services of the demo application, and sends load
+to the frontpage while the effects can be followed on the dashboard. The
+original routing rules are restored when the scenario is finished or interrupted.
+
+In an interactive terminal the scenario waits for a confirmation before each step.`,
+		Example: `  # Run the canary release scenario.
+  backyards demoapp scenario run canary-movies-v3
+
+  # Run a scenario with one minute long steps.
+  backyards demoapp scenario run circuit-breaker-bookings --duration 60`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if options.Namespace == "" {
+				options.Namespace = backyardsDemoNamespace
+			}
+
+			if options.duration < 1 {
+				return errors.New("the duration of the steps must be positive")
+			}
+
+			s, err := getScenario(args[0])
+			if err != nil {
+				return err
+			}
+
+			return c.run(s, options)
+		},
+	}
+
+	cmd.Flags().IntVar(&options.duration, "duration", options.duration, "Duration of the load of each step in seconds")
+
+	return cmd
+}
+
+func (c *scenarioRunCommand) run(s scenario, options *ScenarioRunOptions) error {
+	cl, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	original := make(map[string]serviceRules)
+	for _, service := range s.services() {
+		original[service], err = getServiceRules(cl, types.NamespacedName{
+			Name:      service,
+			Namespace: options.Namespace,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var restoreOnce sync.Once
+	var restoreErr error
+	restore := func() error {
+		restoreOnce.Do(func() {
+			log.Info("restoring the original routing rules")
+			restoreErr = applyStep(cl, options.Namespace, original, scenarioStep{})
+		})
+		return restoreErr
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+	go func() {
+		if _, ok := <-signals; ok {
+			log.Warn("scenario interrupted")
+			if err := restore(); err != nil {
+				log.Error(err)
+			}
+			os.Exit(1)
+		}
+	}()
+
+	log.Infof("running scenario %s: %s", s.Name, s.Description)
+	log.Info("open the dashboard with 'backyards dashboard' to follow the scenario")
+
+	results, err := c.runSteps(cl, s, original, options)
+	if err != nil {
+		if restoreErr := restore(); restoreErr != nil {
+			log.Error(restoreErr)
+		}
+		return err
+	}
+
+	err = restore()
+	if err != nil {
+		return err
+	}
+
+	return load.OutputPhaseResults(c.cli, results)
+}
+
+func (c *scenarioRunCommand) runSteps(cl client.Client, s scenario, original map[string]serviceRules, options *ScenarioRunOptions) ([]load.PhaseResult, error) {
+	results := make([]load.PhaseResult, 0, len(s.steps))
+	for i, step := range s.steps {
+		log.Infof("step %d/%d %s: %s", i+1, len(s.steps), step.name, step.description)
+
+		if c.cli.InteractiveTerminal() {
+			confirmed := false
+			err := survey.AskOne(&survey.Confirm{Message: "Continue with the step?", Default: true}, &confirmed)
+			if err != nil {
+				return nil, errors.WrapIf(err, "could not ask for confirmation")
+			}
+			if !confirmed {
+				return nil, errors.New("scenario cancelled")
+			}
+		}
+
+		err := applyStep(cl, options.Namespace, original, step)
+		if err != nil {
+			return nil, err
+		}
+
+		log.WithFields(log.Fields{
+			"rps":      step.rps,
+			"duration": options.duration,
+		}).Info("sending load to the frontpage")
+
+		response, err := load.GenerateLoad(c.cli, graphql.GenerateLoadRequest{
+			Namespace: options.Namespace,
+			Service:   "frontpage",
+			Port:      8080,
+			Endpoint:  "/",
+			Method:    "GET",
+			Frequency: step.rps,
+			Duration:  options.duration,
+		})
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "scenario step failed", "step", step.name)
+		}
+
+		requests := 0
+		for _, count := range response {
+			requests += count
+		}
+		results = append(results, load.PhaseResult{
+			Phase:    step.name,
+			Rate:     fmt.Sprintf("%drps", step.rps),
+			Duration: options.duration,
+			Requests: requests,
+			Codes:    load.Summarize(response),
+		})
+	}
+
+	return results, nil
+}
+
+func getServiceRules(cl client.Client, name types.NamespacedName) (serviceRules, error) {
+	var rules serviceRules
+
+	var service corev1.Service
+	err := cl.Get(context.Background(), name, &service)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return rules, errors.NewWithDetails("service of the demo application not found, install it with 'backyards demoapp install'", "service", name)
+		}
+		return rules, errors.WrapIfWithDetails(err, "could not get service", "service", name)
+	}
+	if len(service.Spec.Ports) == 0 {
+		return rules, errors.NewWithDetails("service has no ports", "service", name)
+	}
+	rules.port = uint32(service.Spec.Ports[0].Port)
+
+	for _, gvk := range []schema.GroupVersionKind{virtualServiceGVK, destinationRuleGVK} {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		err = cl.Get(context.Background(), name, obj)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return rules, errors.WrapIfWithDetails(err, "could not get routing rule", "kind", gvk.Kind, "name", name)
+		}
+		if gvk == virtualServiceGVK {
+			rules.virtualService = obj
+		} else {
+			rules.destinationRule = obj
+		}
+	}
+
+	return rules, nil
+}
+
+// applyStep sets the routing rules of the services to the original ones changed by the step
+func applyStep(cl client.Client, namespace string, original map[string]serviceRules, step scenarioStep) error {
+	services := make([]string, 0, len(original))
+	for service := range original {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	for _, service := range services {
+		name := types.NamespacedName{
+			Name:      service,
+			Namespace: namespace,
+		}
+
+		virtualService, err := desiredVirtualService(name, original[service], step)
+		if err != nil {
+			return err
+		}
+		err = syncRoutingRule(cl, virtualServiceGVK, name, virtualService)
+		if err != nil {
+			return err
+		}
+
+		destinationRule, err := desiredDestinationRule(name, original[service], step)
+		if err != nil {
+			return err
+		}
+		err = syncRoutingRule(cl, destinationRuleGVK, name, destinationRule)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func desiredVirtualService(name types.NamespacedName, original serviceRules, step scenarioStep) (*unstructured.Unstructured, error) {
+	weights, shifted := step.routes[name.Name]
+	fault := step.faults[name.Name]
+	if !shifted && fault == nil {
+		return original.virtualService, nil
+	}
+
+	virtualService := v1alpha3.VirtualService{
+		Spec: v1alpha3.VirtualServiceSpec{
+			Hosts: []string{name.Name},
+		},
+	}
+	if original.virtualService != nil {
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(original.virtualService.Object, &virtualService)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not convert virtual service", "name", name)
+		}
+	}
+
+	if len(virtualService.Spec.HTTP) == 0 {
+		virtualService.Spec.HTTP = []v1alpha3.HTTPRoute{
+			{
+				Route: []v1alpha3.HTTPRouteDestination{
+					{
+						Destination: v1alpha3.Destination{Host: name.Name},
+						Weight:      100,
+					},
+				},
+			},
+		}
+	}
+
+	// the step is patched into the existing routes, so that their matches, retries and timeouts are kept
+	for i := range virtualService.Spec.HTTP {
+		route := &virtualService.Spec.HTTP[i]
+		if route.Redirect != nil {
+			continue
+		}
+		if shifted {
+			route.Route = shiftedDestinations(name.Name, weights, route.Route)
+		}
+		if fault != nil {
+			route.Fault = fault
+		}
+		// the port selector of the destinations cannot be omitted by these types, and an empty one is invalid
+		for j := range route.Route {
+			if route.Route[j].Destination.Port.Name == "" && route.Route[j].Destination.Port.Number == 0 {
+				route.Route[j].Destination.Port.Number = original.port
+			}
+		}
+	}
+
+	return toUnstructured(virtualServiceGVK, name, &virtualService.Spec)
+}
+
+// shiftedDestinations returns the destinations of the subsets with the given weights, keeping the port
+// selected by the current destinations
+func shiftedDestinations(host string, weights map[string]int, current []v1alpha3.HTTPRouteDestination) []v1alpha3.HTTPRouteDestination {
+	var port v1alpha3.PortSelector
+	if len(current) > 0 {
+		port = current[0].Destination.Port
+	}
+
+	subsets := make([]string, 0, len(weights))
+	for subset := range weights {
+		subsets = append(subsets, subset)
+	}
+	sort.Strings(subsets)
+
+	destinations := make([]v1alpha3.HTTPRouteDestination, 0, len(subsets))
+	for _, subset := range subsets {
+		destinations = append(destinations, v1alpha3.HTTPRouteDestination{
+			Destination: v1alpha3.Destination{
+				Host:   host,
+				Subset: subset,
+				Port:   port,
+			},
+			Weight: weights[subset],
+		})
+	}
+
+	return destinations
+}
+
+func desiredDestinationRule(name types.NamespacedName, original serviceRules, step scenarioStep) (*unstructured.Unstructured, error) {
+	policy := step.circuitBreakers[name.Name]
+	if policy == nil {
+		return original.destinationRule, nil
+	}
+
+	destinationRule := v1alpha3.DestinationRule{
+		Spec: v1alpha3.DestinationRuleSpec{
+			Host: name.Name,
+			// the demo application is installed with mutual TLS, as its own destination rules set it
+			TrafficPolicy: &v1alpha3.TrafficPolicy{
+				TLS: &v1alpha3.TLSSettings{
+					Mode: v1alpha3.TLSmodeIstioMutual,
+				},
+			},
+		},
+	}
+	if original.destinationRule != nil {
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(original.destinationRule.Object, &destinationRule)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not convert destination rule", "name", name)
+		}
+		if destinationRule.Spec.TrafficPolicy == nil {
+			destinationRule.Spec.TrafficPolicy = &v1alpha3.TrafficPolicy{}
+		}
+	}
+
+	destinationRule.Spec.TrafficPolicy.ConnectionPool = policy.ConnectionPool
+	destinationRule.Spec.TrafficPolicy.OutlierDetection = policy.OutlierDetection
+
+	return toUnstructured(destinationRuleGVK, name, &destinationRule.Spec)
+}
+
+func toUnstructured(gvk schema.GroupVersionKind, name types.NamespacedName, spec interface{}) (*unstructured.Unstructured, error) {
+	// the unstructured converter keeps the unsigned integers of the types, which are not valid JSON values
+	var content map[string]interface{}
+	raw, err := json.Marshal(spec)
+	if err == nil {
+		err = json.Unmarshal(raw, &content)
+	}
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not convert routing rule", "kind", gvk.Kind, "name", name)
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name.Name)
+	obj.SetNamespace(name.Namespace)
+	obj.Object["spec"] = content
+
+	return obj, nil
+}
+
+// syncRoutingRule creates, updates or deletes the routing rule so that its spec matches the desired one
+func syncRoutingRule(cl client.Client, gvk schema.GroupVersionKind, name types.NamespacedName, desired *unstructured.Unstructured) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	err := cl.Get(context.Background(), name, current)
+	if k8serrors.IsNotFound(err) {
+		if desired == nil {
+			return nil
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetName(name.Name)
+		obj.SetNamespace(name.Namespace)
+		obj.Object["spec"] = desired.Object["spec"]
+		err = cl.Create(context.Background(), obj)
+		return errors.WrapIfWithDetails(err, "could not create routing rule", "kind", gvk.Kind, "name", name)
+	}
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not get routing rule", "kind", gvk.Kind, "name", name)
+	}
+
+	if desired == nil {
+		err = cl.Delete(context.Background(), current)
+		return errors.WrapIfWithDetails(err, "could not delete routing rule", "kind", gvk.Kind, "name", name)
+	}
+
+	current.Object["spec"] = desired.Object["spec"]
+	err = cl.Update(context.Background(), current)
+	return errors.WrapIfWithDetails(err, "could not update routing rule", "kind", gvk.Kind, "name", name)
+}
@@ -16,12 +16,10 @@ package demoapp
 
 import (
 	"fmt"
-	"time"
 
 	"emperror.dev/errors"
 	"github.com/spf13/cobra"
 	"istio.io/operator/pkg/object"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 	"github.com/banzaicloud/backyards-cli/pkg/helm"
@@ -33,7 +31,7 @@ type uninstallCommand struct {
 }
 
 type UninstallOptions struct {
-	namespace string
+	Namespace string
 
 	DumpResources bool
 }
@@ -56,16 +54,16 @@ func NewUninstallCommand(cli cli.CLI, options *UninstallOptions) *cobra.Command
 The command automatically removes the resources.
 It can only dump the removable resources with the '--dump-resources' option.`,
 		Example: `  # Default uninstall.
-  backyards canary uninstall
+  backyards demoapp uninstall
 
-  # Uninstall Canary feature from a non-default namespace.
-  backyards canary uninstall install -n custom-istio-ns`,
+  # Uninstall demo application from a non-default namespace.
+  backyards demoapp uninstall --demo-namespace my-demo`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceErrors = true
 			cmd.SilenceUsage = true
 
-			if options.namespace == "" {
-				options.namespace = backyardsDemoNamespace
+			if options.Namespace == "" {
+				options.Namespace = backyardsDemoNamespace
 			}
 
 			return c.run(cli, options)
@@ -78,7 +76,7 @@ It can only dump the removable resources with the '--dump-resources' option.`,
 }
 
 func (c *uninstallCommand) run(cli cli.CLI, options *UninstallOptions) error {
-	objects, err := getBackyardsDemoObjects(options.namespace)
+	objects, err := GetBackyardsDemoObjects(options.Namespace)
 	if err != nil {
 		return err
 	}
@@ -107,12 +105,12 @@ func (c *uninstallCommand) deleteResources(objects object.K8sObjects) error {
 		return err
 	}
 
-	err = k8s.DeleteResources(client, c.cli.LabelManager(), objects, k8s.WaitForResourceConditions(wait.Backoff{
-		Duration: time.Second * 5,
-		Factor:   1,
-		Jitter:   0,
-		Steps:    24,
-	}, k8s.NonExistsConditionCheck))
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	err = k8s.DeleteResources(client, c.cli.LabelManager(), c.cli.Logger(), objects, k8s.WaitForResourceConditions(config, c.cli.WaitOptions(), k8s.NonExistsConditionCheck))
 	if err != nil {
 		return errors.WrapIf(err, "could not delete k8s resources")
 	}
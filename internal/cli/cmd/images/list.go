@@ -0,0 +1,99 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/images"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+)
+
+type listCommand struct {
+	cli cli.CLI
+}
+
+// ImageReference is an image deployed as part of a component
+type ImageReference struct {
+	Component string `json:"component"`
+	Image     string `json:"image"`
+}
+
+func NewListCommand(cli cli.CLI) *cobra.Command {
+	c := &listCommand{
+		cli: cli,
+	}
+
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Short:   "List every image the CLI would deploy",
+		Long: `List every image the CLI would deploy.
+
+The list contains the images of Backyards, Istio, cert-manager, the Canary feature
+and the demo application, with the '--image-registry' override applied.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return c.run()
+		},
+	}
+}
+
+func (c *listCommand) run() error {
+	references, err := getImageReferences()
+	if err != nil {
+		return err
+	}
+
+	ctx := &output.Context{
+		Out:     c.cli.Out(),
+		Color:   c.cli.Color(),
+		Format:  c.cli.OutputFormat(),
+		Fields:  []string{"Component", "Image"},
+		Headers: []string{"Component", "Image"},
+	}
+
+	err = output.Output(ctx, references)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
+
+func getImageReferences() ([]ImageReference, error) {
+	components, err := cmd.GetDefaultComponentObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	references := make([]ImageReference, 0)
+	for _, component := range components {
+		for _, image := range images.List(component.Objects) {
+			references = append(references, ImageReference{
+				Component: component.Name,
+				Image:     image,
+			})
+		}
+	}
+
+	return references, nil
+}
@@ -0,0 +1,170 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"os"
+	"os/exec"
+
+	"emperror.dev/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/images"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+)
+
+type mirrorCommand struct {
+	cli cli.CLI
+}
+
+type MirrorOptions struct {
+	registry string
+	dryRun   bool
+}
+
+// MirroredImage is an image copied to the target registry
+type MirroredImage struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+}
+
+func NewMirrorOptions() *MirrorOptions {
+	return &MirrorOptions{}
+}
+
+func NewMirrorCommand(cli cli.CLI, options *MirrorOptions) *cobra.Command {
+	c := &mirrorCommand{
+		cli: cli,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "mirror --to registry",
+		Args:  cobra.NoArgs,
+		Short: "Copy every image the CLI would deploy to a registry",
+		Long: `Copy every image the CLI would deploy to a registry.
+
+The images keep their repository path and tag, so the registry can be used with
+the '--image-registry' option of the install commands afterwards.
+
+The images are copied with skopeo if it is available, otherwise they are pulled,
+tagged and pushed with docker. Both use their own registry credentials.`,
+		Example: `  # Copy the images to a private registry.
+  backyards images mirror --to registry.example.com/backyards
+
+  # Install using the private registry.
+  backyards install -a --image-registry registry.example.com/backyards`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return c.run(options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.registry, "to", options.registry, "Registry to copy the images to")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", options.dryRun, "Only list the images which would be copied")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func (c *mirrorCommand) run(options *MirrorOptions) error {
+	// the sources are the original images regardless of any registry override
+	viper.Set("images.registry", "")
+
+	references, err := getImageReferences()
+	if err != nil {
+		return err
+	}
+
+	mirrored := make([]MirroredImage, 0)
+	seen := make(map[string]bool)
+	for _, reference := range references {
+		if seen[reference.Image] {
+			continue
+		}
+		seen[reference.Image] = true
+		mirrored = append(mirrored, MirroredImage{
+			Source:      reference.Image,
+			Destination: images.RewriteReference(reference.Image, options.registry),
+		})
+	}
+
+	if !options.dryRun {
+		copyFunc, err := getCopyFunc()
+		if err != nil {
+			return err
+		}
+
+		for _, image := range mirrored {
+			log.Infof("copying %s to %s", image.Source, image.Destination)
+			err = copyFunc(image.Source, image.Destination)
+			if err != nil {
+				return errors.WrapIfWithDetails(err, "could not copy image", "source", image.Source, "destination", image.Destination)
+			}
+		}
+	}
+
+	ctx := &output.Context{
+		Out:     c.cli.Out(),
+		Color:   c.cli.Color(),
+		Format:  c.cli.OutputFormat(),
+		Fields:  []string{"Source", "Destination"},
+		Headers: []string{"Source", "Destination"},
+	}
+
+	err = output.Output(ctx, mirrored)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
+
+// getCopyFunc returns a function which copies an image using the available container tools
+func getCopyFunc() (func(source, destination string) error, error) {
+	if skopeo, err := exec.LookPath("skopeo"); err == nil {
+		return func(source, destination string) error {
+			return runTool(skopeo, "copy", "docker://"+source, "docker://"+destination)
+		}, nil
+	}
+
+	if docker, err := exec.LookPath("docker"); err == nil {
+		return func(source, destination string) error {
+			err := runTool(docker, "pull", source)
+			if err != nil {
+				return err
+			}
+			err = runTool(docker, "tag", source, destination)
+			if err != nil {
+				return err
+			}
+			return runTool(docker, "push", destination)
+		}, nil
+	}
+
+	return nil, errors.New("neither skopeo nor docker is available to copy images")
+}
+
+func runTool(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	return errors.WrapIfWithDetails(cmd.Run(), "command failed", "command", name, "args", args)
+}
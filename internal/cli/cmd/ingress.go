@@ -0,0 +1,289 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"emperror.dev/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"istio.io/operator/pkg/object"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+)
+
+const (
+	ingressCertificateName = "backyards-ingress"
+	ingressTLSSecretName   = "backyards-ingress-tls"
+
+	selfSignedIssuer  = "selfsigned"
+	letsEncryptIssuer = "letsencrypt"
+
+	letsEncryptServer = "https://acme-v02.api.letsencrypt.org/directory"
+)
+
+// ingressOptions are the settings of the Ingress exposing Backyards
+type ingressOptions struct {
+	ingressHost   string
+	ingressClass  string
+	ingressTLS    bool
+	ingressIssuer string
+	acmeEmail     string
+}
+
+// validateIngressOptions checks the consistency of the ingress related install flags
+func validateIngressOptions(options *InstallOptions) error {
+	if options.ingressHost == "" {
+		if options.ingressTLS {
+			return errors.New("the --tls flag requires an ingress host")
+		}
+		return nil
+	}
+
+	if !options.ingressTLS {
+		return nil
+	}
+
+	switch options.ingressIssuer {
+	case selfSignedIssuer:
+	case letsEncryptIssuer:
+		if options.acmeEmail == "" {
+			return errors.New("the letsencrypt issuer requires an e-mail address, use the --acme-email flag")
+		}
+	default:
+		return errors.NewWithDetails("unknown issuer", "issuer", options.ingressIssuer, "supported", []string{selfSignedIssuer, letsEncryptIssuer})
+	}
+
+	return nil
+}
+
+// setValues exposes Backyards through an Ingress on the given host
+func (o ingressOptions) setValues(values *Values) {
+	if o.ingressHost == "" {
+		return
+	}
+
+	values.Ingress.Enabled = true
+	values.Ingress.Hosts = []string{o.ingressHost}
+	if o.ingressClass != "" {
+		if values.Ingress.Annotations == nil {
+			values.Ingress.Annotations = make(map[string]string)
+		}
+		values.Ingress.Annotations["kubernetes.io/ingress.class"] = o.ingressClass
+	}
+
+	if o.ingressTLS {
+		values.CertManager.Enabled = true
+		values.Ingress.TLS = []IngressTLS{
+			{
+				SecretName: ingressTLSSecretName,
+				Hosts:      []string{o.ingressHost},
+			},
+		}
+		values.Ingress.Issuer = IngressIssuer{
+			Type:  o.ingressIssuer,
+			Email: o.acmeEmail,
+			Class: o.ingressClass,
+		}
+	}
+}
+
+// getIngressCertificateObjects returns the cert-manager Issuer and Certificate of the ingress TLS settings
+func getIngressCertificateObjects(values Values) object.K8sObjects {
+	if !values.Ingress.Enabled || len(values.Ingress.TLS) == 0 || values.Ingress.Issuer.Type == "" {
+		return nil
+	}
+
+	namespace := viper.GetString("backyards.namespace")
+
+	issuerSpec := map[string]interface{}{
+		"selfSigned": map[string]interface{}{},
+	}
+	if values.Ingress.Issuer.Type == letsEncryptIssuer {
+		http01 := map[string]interface{}{}
+		if values.Ingress.Issuer.Class != "" {
+			http01["class"] = values.Ingress.Issuer.Class
+		}
+		issuerSpec = map[string]interface{}{
+			"acme": map[string]interface{}{
+				"server": letsEncryptServer,
+				"email":  values.Ingress.Issuer.Email,
+				"privateKeySecretRef": map[string]interface{}{
+					"name": ingressCertificateName + "-acme",
+				},
+				"solvers": []interface{}{
+					map[string]interface{}{
+						"http01": map[string]interface{}{
+							"ingress": http01,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	hosts := make([]interface{}, 0)
+	for _, host := range values.Ingress.TLS[0].Hosts {
+		hosts = append(hosts, host)
+	}
+
+	issuer := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "certmanager.k8s.io/v1alpha1",
+			"kind":       "Issuer",
+			"metadata": map[string]interface{}{
+				"name":      ingressCertificateName,
+				"namespace": namespace,
+			},
+			"spec": issuerSpec,
+		},
+	}
+
+	certificate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "certmanager.k8s.io/v1alpha1",
+			"kind":       "Certificate",
+			"metadata": map[string]interface{}{
+				"name":      ingressCertificateName,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"secretName": values.Ingress.TLS[0].SecretName,
+				"commonName": hosts[0],
+				"dnsNames":   hosts,
+				"issuerRef": map[string]interface{}{
+					"name": ingressCertificateName,
+					"kind": "Issuer",
+				},
+			},
+		},
+	}
+
+	return object.K8sObjects{
+		object.NewK8sObject(issuer, nil, nil),
+		object.NewK8sObject(certificate, nil, nil),
+	}
+}
+
+// objectsToWaitFor leaves the ingress Certificate of a Let's Encrypt issuer out of the objects to wait for,
+// as it can only be issued once the DNS records of the hosts point at the ingress
+func objectsToWaitFor(logger log.FieldLogger, values Values, objects object.K8sObjects) object.K8sObjects {
+	if !values.Ingress.Enabled || len(values.Ingress.TLS) == 0 || values.Ingress.Issuer.Type != letsEncryptIssuer {
+		return objects
+	}
+
+	waited := make(object.K8sObjects, 0, len(objects))
+	for _, obj := range objects {
+		if obj.Kind == "Certificate" && obj.Name == ingressCertificateName {
+			logger.Warnf("the certificate of %s is issued by Let's Encrypt once the DNS records point at the ingress", strings.Join(values.Ingress.TLS[0].Hosts, ", "))
+			continue
+		}
+		waited = append(waited, obj)
+	}
+
+	return waited
+}
+
+// saveIngressEndpoint writes the URL of the ingress and the CA of its self-signed certificate into the CLI
+// config for the current context and namespace, so that the other commands reach Backyards through the ingress
+func saveIngressEndpoint(cl cli.CLI, client k8sclient.Client, values Values) error {
+	if !values.Ingress.Enabled || len(values.Ingress.Hosts) == 0 {
+		return nil
+	}
+
+	kubeContext, err := cl.CurrentContext()
+	if err != nil {
+		return err
+	}
+
+	host := values.Ingress.Hosts[0]
+	url := fmt.Sprintf("http://%s", host)
+	caFile := ""
+
+	if len(values.Ingress.TLS) > 0 {
+		url = fmt.Sprintf("https://%s", host)
+	}
+	if basePath := strings.TrimSuffix(values.Ingress.BasePath, "/"); basePath != "" {
+		url += basePath
+	}
+
+	if len(values.Ingress.TLS) > 0 && values.Ingress.Issuer.Type == selfSignedIssuer {
+		var secret corev1.Secret
+		err := client.Get(context.Background(), types.NamespacedName{
+			Name:      values.Ingress.TLS[0].SecretName,
+			Namespace: viper.GetString("backyards.namespace"),
+		}, &secret)
+		if err != nil {
+			return errors.WrapIf(err, "could not get ingress certificate")
+		}
+
+		ca := secret.Data["ca.crt"]
+		if len(ca) == 0 {
+			ca = secret.Data[corev1.TLSCertKey]
+		}
+
+		caFile = filepath.Join(filepath.Dir(cli.ConfigFile()), host+".ca.crt")
+		err = os.MkdirAll(filepath.Dir(caFile), 0700)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not create config directory", "file", caFile)
+		}
+		err = ioutil.WriteFile(caFile, ca, 0600)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not write CA certificate", "file", caFile)
+		}
+	}
+
+	err = cli.SaveEndpoint(cli.SavedEndpoint{
+		Context:   kubeContext,
+		Namespace: viper.GetString("backyards.namespace"),
+		URL:       url,
+		CACert:    caFile,
+	})
+	if err != nil {
+		return err
+	}
+
+	cl.Logger().Infof("Backyards is available at %s, the URL is saved into %s for context %s", url, cli.ConfigFile(), kubeContext)
+
+	return nil
+}
+
+// removeIngressEndpoint removes the saved URL of the ingress for the current context and namespace
+func removeIngressEndpoint(cl cli.CLI) error {
+	kubeContext, err := cl.CurrentContext()
+	if err != nil {
+		return err
+	}
+
+	removed, err := cli.RemoveEndpoint(kubeContext, viper.GetString("backyards.namespace"))
+	if err != nil {
+		return err
+	}
+	if removed {
+		cl.Logger().Infof("the saved Backyards URL of context %s is removed from %s", kubeContext, cli.ConfigFile())
+	}
+
+	return nil
+}
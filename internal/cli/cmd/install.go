@@ -18,9 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
-	"time"
 
 	"emperror.dev/errors"
 	"github.com/AlecAivazis/survey/v2"
@@ -28,9 +26,7 @@ import (
 	"github.com/spf13/viper"
 	"go.uber.org/multierr"
 	"istio.io/operator/pkg/object"
-	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
@@ -40,23 +36,19 @@ import (
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/demoapp"
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
+	"github.com/banzaicloud/backyards-cli/internal/release"
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 	"github.com/banzaicloud/backyards-cli/pkg/helm"
 	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
 	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
 )
 
 const (
-	requirementNotFoundErrorTemplate = "Unable to install Backyards: %s\n"
+	requirementNotFoundErrorTemplate = "unable to install Backyards: %s"
 	defaultReleaseName               = "backyards"
 )
 
-var (
-	certManagerPodLabels = map[string]string{
-		"app": "cert-manager",
-	}
-)
-
 type installCommand struct {
 	cli                      cli.CLI
 	shouldInstallIstio       bool
@@ -67,9 +59,10 @@ type installCommand struct {
 }
 
 type InstallOptions struct {
-	releaseName    string
-	istioNamespace string
-	dumpResources  bool
+	releaseName   string
+	dumpResources bool
+	skipPreflight bool
+	profile       string
 
 	installCanary      bool
 	installDemoapp     bool
@@ -79,9 +72,56 @@ type InstallOptions struct {
 	enableAuth         bool
 	installEverything  bool
 	runDemo            bool
+	demoNamespace      string
 
 	apiImage string
 	webImage string
+
+	ingressOptions
+	externalEndpoints
+	tracingStorage
+}
+
+// releaseOptions holds the install options recorded along with a release
+type releaseOptions struct {
+	IstioNamespace  string `json:"istioNamespace"`
+	EnableAuditSink bool   `json:"enableAuditSink"`
+	EnableAuth      bool   `json:"enableAuth"`
+	APIImage        string `json:"apiImage,omitempty"`
+	WebImage        string `json:"webImage,omitempty"`
+	Profile         string `json:"profile,omitempty"`
+	ImageRegistry   string `json:"imageRegistry,omitempty"`
+	ImagePullSecret string `json:"imagePullSecret,omitempty"`
+}
+
+// apply sets the recorded options on the values of the revision
+func (o releaseOptions) apply(values *Values) {
+	if o.IstioNamespace != "" {
+		values.Istio.Namespace = o.IstioNamespace
+	}
+	values.AuditSink.Enabled = o.EnableAuditSink
+	if o.EnableAuth {
+		values.CertManager.Enabled = true
+		values.Auth.Method = impersonation
+		values.Impersonation.Enabled = true
+	}
+	setImage(&values.Application.Image, o.APIImage)
+	setImage(&values.Web.Image, o.WebImage)
+}
+
+// setImage overrides the image with the given reference, the tag defaults to latest
+func setImage(image *helm.Image, reference string) {
+	if reference == "" {
+		return
+	}
+
+	imageParts := strings.Split(reference, ":")
+	image.Repository = imageParts[0]
+	if len(imageParts) > 1 {
+		image.Tag = imageParts[1]
+	} else {
+		image.Tag = "latest"
+	}
 }
 
 // patchStringValue specifies a patch operation for a string value
@@ -110,40 +150,64 @@ The command can install every component at once with the '--install-everything'
 		Example: `  # Default install.
   backyards install
 
+  # Install Backyards with small resource requests on a local cluster.
+  backyards install --profile minimal
+
   # Install Backyards into a non-default namespace.
-  backyards install -n backyards-system`,
+  backyards install -n backyards-system
+
+  # Expose Backyards on a host with a Let's Encrypt certificate.
+  backyards install --ingress-host backyards.example.com --tls --issuer letsencrypt --acme-email admin@example.com
+
+  # Use the existing Prometheus and Jaeger of the cluster instead of the bundled ones.
+  backyards install --prometheus-url http://prometheus.monitoring:9090 --tracing-address jaeger-collector.monitoring:9411
+
+  # Keep the traces of a week on a persistent volume.
+  backyards install --tracing-persistence --tracing-storage-size 20Gi --tracing-retention 168h
+
+  # Install Backyards with every component on several clusters.
+  backyards install -a --contexts staging,prod-eu,prod-us`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
 
 			cmd.SilenceErrors = true
 			cmd.SilenceUsage = true
 
-			err = c.shouldInstallComponents(options)
+			_, err = getSizingProfile(options.profile)
+			if err != nil {
+				return err
+			}
+
+			err = validateIngressOptions(options)
 			if err != nil {
 				return err
 			}
 
-			err = c.runSubcommands(options)
+			err = options.externalEndpoints.validate()
 			if err != nil {
 				return err
 			}
 
-			err = c.run(options)
+			err = options.tracingStorage.validate(options.externalEndpoints)
 			if err != nil {
 				return err
 			}
 
-			err = c.runDemoInstall(options)
+			err = c.shouldInstallComponents(options)
 			if err != nil {
 				return err
 			}
 
-			return nil
+			if c.shouldRunDemo && util.IsMultiContext() {
+				return errors.New("the demo application cannot be run against multiple contexts")
+			}
+
+			return util.RunForContexts(c.cli, c.installWithCLI(options))
 		},
 	}
 
 	cmd.Flags().StringVar(&options.releaseName, "release-name", defaultReleaseName, "Name of the release")
-	cmd.Flags().StringVar(&options.istioNamespace, "istio-namespace", istio.DefaultNamespace, "Namespace of Istio sidecar injector")
+	cmd.Flags().StringVar(&options.profile, "profile", profileDefault, "Sizing profile of the components (minimal|default|production), see 'backyards profile show'")
 
 	cmd.Flags().BoolVar(&options.installCanary, "install-canary", options.installCanary, "Install Canary feature as well")
 	cmd.Flags().BoolVar(&options.installDemoapp, "install-demoapp", options.installDemoapp, "Install Demo application as well")
@@ -152,30 +216,78 @@ The command can install every component at once with the '--install-everything'
 	cmd.Flags().BoolVarP(&options.installEverything, "install-everything", "a", options.installEverything, "Install every component at once")
 
 	cmd.Flags().BoolVar(&options.runDemo, "run-demo", options.runDemo, "Send load to demo application and opens up dashboard")
+	cmd.Flags().StringVar(&options.demoNamespace, "demo-namespace", demoapp.GetNamespace(), "Namespace for demo application")
 	cmd.Flags().BoolVar(&options.enableAuditSink, "enable-auditsink", options.enableAuditSink, "Enable deploying the auditsink service and sending audit logs over http")
 	cmd.Flags().BoolVar(&options.enableAuth, "enable-auth", options.enableAuth, "Enable authentication with impersonation")
 
 	cmd.Flags().StringVar(&options.apiImage, "api-image", options.apiImage, "Image for the API")
 	cmd.Flags().StringVar(&options.webImage, "web-image", options.webImage, "Image for the frontend")
 
+	cmd.Flags().StringVar(&options.ingressHost, "ingress-host", options.ingressHost, "Expose Backyards through an Ingress on this host")
+	cmd.Flags().StringVar(&options.ingressClass, "ingress-class", options.ingressClass, "Class of the Ingress")
+	cmd.Flags().BoolVar(&options.ingressTLS, "tls", options.ingressTLS, "Serve the Ingress over TLS with a certificate issued by cert-manager")
+	cmd.Flags().StringVar(&options.ingressIssuer, "issuer", selfSignedIssuer, "Issuer of the Ingress certificate (selfsigned|letsencrypt)")
+	cmd.Flags().StringVar(&options.acmeEmail, "acme-email", options.acmeEmail, "E-mail address of the ACME account used by the letsencrypt issuer")
+
+	options.externalEndpoints.addFlags(cmd)
+	options.tracingStorage.addFlags(cmd)
+
 	cmd.Flags().BoolVarP(&options.dumpResources, "dump-resources", "d", options.dumpResources, "Dump resources to stdout instead of applying them")
+	cmd.Flags().BoolVar(&options.skipPreflight, "skip-preflight", options.skipPreflight, "Skip the preflight checks")
 
-	return cmd
+	return util.SupportMultiContext(cmd)
+}
+
+// installWithCLI returns a function running the whole install with the given CLI
+func (c *installCommand) installWithCLI(options *InstallOptions) func(cli.CLI) error {
+	return func(cli cli.CLI) error {
+		c := &installCommand{
+			cli:                      cli,
+			shouldInstallIstio:       c.shouldInstallIstio,
+			shouldInstallCanary:      c.shouldInstallCanary,
+			shouldInstallCertManager: c.shouldInstallCertManager,
+			shouldInstallDemo:        c.shouldInstallDemo,
+			shouldRunDemo:            c.shouldRunDemo,
+		}
+
+		err := c.runPreflight(options)
+		if err != nil {
+			return err
+		}
+
+		err = c.runSubcommands(options)
+		if err != nil {
+			return err
+		}
+
+		err = c.run(options)
+		if err != nil {
+			return err
+		}
+
+		return c.runDemoInstall(options)
+	}
 }
 
 func (c *installCommand) run(options *InstallOptions) error {
 	err := c.validate(options)
 	if err != nil {
 		errors := multierr.Errors(err)
-		var errorItems string
-		for _, e := range errors {
-			errorItems += "\n - " + e.Error()
+		errorItems := make([]string, len(errors))
+		for i, e := range errors {
+			errorItems[i] = e.Error()
 		}
-		fmt.Fprintf(os.Stderr, requirementNotFoundErrorTemplate, errorItems)
+		c.cli.Logger().Errorf(requirementNotFoundErrorTemplate, strings.Join(errorItems, "; "))
 		return nil
 	}
 
-	values, err := getValues(options.releaseName, options.istioNamespace, func(values *Values) {
+	profile, err := getSizingProfile(options.profile)
+	if err != nil {
+		return err
+	}
+
+	values, err := getValues(options.releaseName, istio.IstioNamespace, func(values *Values) {
+		profile.apply(values)
 		values.AuditSink.Enabled = options.enableAuditSink
 		if shouldCertManagerBeEnabled(options) {
 			values.CertManager.Enabled = true
@@ -185,24 +297,11 @@ func (c *installCommand) run(options *InstallOptions) error {
 			values.Auth.Method = impersonation
 			values.Impersonation.Enabled = true
 		}
-		if options.apiImage != "" {
-			imageParts := strings.Split(options.apiImage, ":")
-			values.Application.Image.Repository = imageParts[0]
-			if len(imageParts) > 1 {
-				values.Application.Image.Tag = imageParts[1]
-			} else {
-				values.Application.Image.Tag = "latest"
-			}
-		}
-		if options.webImage != "" {
-			imageParts := strings.Split(options.webImage, ":")
-			values.Web.Image.Repository = imageParts[0]
-			if len(imageParts) > 1 {
-				values.Web.Image.Tag = imageParts[1]
-			} else {
-				values.Web.Image.Tag = "latest"
-			}
-		}
+		setImage(&values.Application.Image, options.apiImage)
+		setImage(&values.Web.Image, options.webImage)
+		options.ingressOptions.setValues(values)
+		options.externalEndpoints.setValues(values)
+		options.tracingStorage.setValues(values)
 	})
 	if err != nil {
 		return err
@@ -226,17 +325,27 @@ func (c *installCommand) run(options *InstallOptions) error {
 			return err
 		}
 
-		err = k8s.ApplyResources(client, c.cli.LabelManager(), objects)
+		config, err := c.cli.GetK8sConfig()
+		if err != nil {
+			return err
+		}
+
+		err = k8s.ApplyResources(client, c.cli.LabelManager(), c.cli.Logger(), objects)
 		if err != nil {
 			return err
 		}
 
-		err = k8s.WaitForResourcesConditions(client, k8s.NamesWithGVKFromK8sObjects(objects), wait.Backoff{
-			Duration: time.Second * 5,
-			Factor:   1,
-			Jitter:   0,
-			Steps:    24,
-		}, k8s.ExistsConditionCheck, k8s.ReadyReplicasConditionCheck)
+		err = k8s.WaitForResourcesConditions(config, k8s.NamesWithGVKFromK8sObjects(objectsToWaitFor(c.cli.Logger(), values, objects)), c.cli.WaitOptions(), k8s.ExistsConditionCheck, k8s.ReadyConditionCheck)
+		if err != nil {
+			return err
+		}
+
+		err = c.saveRelease(client, options, values, objects)
+		if err != nil {
+			return err
+		}
+
+		err = saveIngressEndpoint(c.cli, client, values)
 		if err != nil {
 			return err
 		}
@@ -251,6 +360,35 @@ func (c *installCommand) run(options *InstallOptions) error {
 	return nil
 }
 
+func (c *installCommand) saveRelease(client k8sclient.Client, options *InstallOptions, values Values, objects object.K8sObjects) error {
+	rawValues, err := json.Marshal(values)
+	if err != nil {
+		return errors.WrapIf(err, "could not marshal values")
+	}
+
+	rawOptions, err := json.Marshal(releaseOptions{
+		IstioNamespace:  istio.IstioNamespace,
+		EnableAuditSink: options.enableAuditSink,
+		EnableAuth:      options.enableAuth,
+		APIImage:        options.apiImage,
+		WebImage:        options.webImage,
+		Profile:         options.profile,
+		ImageRegistry:   viper.GetString("images.registry"),
+		ImagePullSecret: viper.GetString("images.pullSecret"),
+	})
+	if err != nil {
+		return errors.WrapIf(err, "could not marshal install options")
+	}
+
+	return release.NewStore(client, viper.GetString("backyards.namespace")).Save(&release.Record{
+		Name:       options.releaseName,
+		CLIVersion: c.cli.GetRootCommand().Version,
+		Values:     rawValues,
+		Options:    rawOptions,
+		Objects:    objectKeys(objects),
+	})
+}
+
 func getValues(releaseName, istioNamespace string, valueOverrideFunc func(values *Values)) (Values, error) {
 	var values Values
 
@@ -274,6 +412,16 @@ func getValues(releaseName, istioNamespace string, valueOverrideFunc func(values
 }
 
 func getBackyardsObjects(values Values) (object.K8sObjects, error) {
+	objects, err := renderBackyardsObjects(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.RewriteImages(objects), nil
+}
+
+// renderBackyardsObjects renders the objects of the chart without rewriting their images
+func renderBackyardsObjects(values Values) (object.K8sObjects, error) {
 	rawValues, err := yaml.Marshal(values)
 	if err != nil {
 		return nil, errors.WrapIf(err, "could not marshal yaml values")
@@ -289,6 +437,8 @@ func getBackyardsObjects(values Values) (object.K8sObjects, error) {
 		return nil, errors.WrapIf(err, "could not render helm manifest objects")
 	}
 
+	objects = append(objects, getIngressCertificateObjects(values)...)
+
 	return objects, nil
 }
 
@@ -299,16 +449,21 @@ func (c *installCommand) setTracingAddress(values Values) error {
 		return err
 	}
 
+	address := values.Tracing.Address
+	if address == "" {
+		address = fmt.Sprintf("%s.%s:%d", values.Tracing.Service.Name, viper.GetString("backyards.namespace"), values.Tracing.Service.ExternalPort)
+	}
+
 	payload := []patchStringValue{{
 		Op:    "replace",
 		Path:  "/spec/tracing/zipkin/address",
-		Value: fmt.Sprintf("%s.%s:%d", values.Tracing.Service.Name, viper.GetString("backyards.namespace"), values.Tracing.Service.ExternalPort),
+		Value: address,
 	}}
 	payloadBytes, _ := json.Marshal(payload)
 
 	istioCR := v1beta1.Istio{}
-	istioCR.Name = istio.IstioCRName
-	istioCR.Namespace = istio.IstioNamespace
+	istioCR.Name = values.Istio.CRName
+	istioCR.Namespace = values.Istio.Namespace
 	err = cl.Patch(context.Background(), &istioCR, client.ConstantPatch(types.JSONPatchType, payloadBytes))
 	if err != nil {
 		return err
@@ -321,7 +476,7 @@ func (c *installCommand) validate(options *InstallOptions) error {
 	var istioHealthy bool
 	var combinedErr error
 
-	istioExists, istioHealthy, err := c.istioRunning(options.istioNamespace)
+	istioExists, istioHealthy, err := c.istioRunning(istio.IstioNamespace)
 	if err != nil {
 		return errors.WrapIf(err, "failed to check Istio state")
 	}
@@ -329,15 +484,15 @@ func (c *installCommand) validate(options *InstallOptions) error {
 	if !istioExists {
 		combinedErr = errors.Combine(combinedErr,
 			errors.Errorf("could not find Istio sidecar injector in '%s' namespace, "+
-				"use the --install-istio flag", options.istioNamespace))
+				"use the --install-istio flag", istio.IstioNamespace))
 	}
 	if istioExists && !istioHealthy {
 		combinedErr = errors.Combine(combinedErr,
-			errors.Errorf("Istio sidecar injector not healthy yet in '%s' namespace", options.istioNamespace))
+			errors.Errorf("Istio sidecar injector not healthy yet in '%s' namespace", istio.IstioNamespace))
 	}
 
 	if shouldCertManagerBeEnabled(options) {
-		certManagerExists, certManagerHealthy, err := c.certManagerRunning()
+		certManagerNamespace, certManagerExists, certManagerHealthy, err := c.certManagerRunning()
 		if err != nil {
 			return errors.WrapIf(err, "failed to check cert-manager state")
 		}
@@ -346,11 +501,11 @@ func (c *installCommand) validate(options *InstallOptions) error {
 			combinedErr = errors.Combine(combinedErr,
 				errors.Errorf("could not find cert-manager controller in '%s' namespace, "+
 					"use the --install-cert-manager flag or disable it using --disable-cert-manager "+
-					"which disables dependent services as well", certmanager.CertManagerNamespace))
+					"which disables dependent services as well", certManagerNamespace))
 		}
 		if certManagerExists && !certManagerHealthy {
 			combinedErr = errors.Combine(combinedErr,
-				errors.Errorf("cert-manager controller not healthy yet in '%s' namespace", certmanager.CertManagerNamespace))
+				errors.Errorf("cert-manager controller not healthy yet in '%s' namespace", certManagerNamespace))
 		}
 	}
 
@@ -363,46 +518,35 @@ func (c *installCommand) istioRunning(istioNamespace string) (exists bool, healt
 		err = errors.WrapIf(err, "could not get k8s client")
 		return
 	}
-	var pods v1.PodList
-	err = cl.List(context.Background(), &pods, client.InNamespace(istioNamespace), client.MatchingLabels(util.SidecarPodLabels))
-	if err != nil {
-		err = errors.WrapIf(err, "could not list istio pods")
-		return
-	}
-	if len(pods.Items) > 0 {
-		exists = true
-	}
-	for _, pod := range pods.Items {
-		if pod.Status.Phase == v1.PodRunning {
-			healthy = true
-			break
-		}
-	}
-	return
+
+	return util.PodsRunning(cl, istioNamespace, util.SidecarPodLabels)
 }
 
-func (c *installCommand) certManagerRunning() (exists bool, healthy bool, err error) {
+// certManagerRunning checks the cert-manager controller, which may be a compatible one installed by other means
+func (c *installCommand) certManagerRunning() (namespace string, exists bool, healthy bool, err error) {
+	namespace = certmanager.CertManagerNamespace
+
 	cl, err := c.cli.GetK8sClient()
 	if err != nil {
 		err = errors.WrapIf(err, "could not get k8s client")
 		return
 	}
-	var certManagerPods v1.PodList
-	err = cl.List(context.Background(), &certManagerPods, client.InNamespace(certmanager.CertManagerNamespace),
-		client.MatchingLabels(certManagerPodLabels))
+
+	installation, err := certmanager.DetectInstallation(cl)
 	if err != nil {
-		err = errors.WrapIf(err, "failed to list cert-manager controller pods")
 		return
 	}
-	if len(certManagerPods.Items) > 0 {
-		exists = true
-	}
-	for _, pod := range certManagerPods.Items {
-		if pod.Status.Phase == v1.PodRunning {
-			healthy = true
-			break
+	if installation != nil {
+		namespace = installation.Namespace
+		if !installation.Managed {
+			err = certmanager.CheckCompatibility(cl, installation)
+			if err != nil {
+				return
+			}
 		}
 	}
+
+	exists, healthy, err = util.PodsRunning(cl, namespace, util.CertManagerPodLabels)
 	return
 }
 
@@ -467,10 +611,11 @@ func (c *installCommand) shouldInstallComponents(options *InstallOptions) error
 	}
 	c.shouldInstallDemo = installDemoExplicitly || installDemoInteractively
 
-	runDemoExplicitly := options.runDemo || options.installEverything
+	// the demo is not run as part of a multi-context install, as it opens the dashboard
+	runDemoExplicitly := options.runDemo || (options.installEverything && !util.IsMultiContext())
 	runDemoInteractively := false
 
-	if !runDemoExplicitly && c.cli.InteractiveTerminal() {
+	if !runDemoExplicitly && !util.IsMultiContext() && c.cli.InteractiveTerminal() {
 		err := survey.AskOne(&survey.Confirm{
 			Renderer: survey.Renderer{},
 			Message:  "Run demo application (optional). Press enter to skip",
@@ -485,6 +630,31 @@ func (c *installCommand) shouldInstallComponents(options *InstallOptions) error
 	return nil
 }
 
+func (c *installCommand) runPreflight(options *InstallOptions) error {
+	if options.dumpResources || options.skipPreflight {
+		return nil
+	}
+
+	scmdOptions := NewPreflightOptions()
+	scmdOptions.releaseName = options.releaseName
+	scmdOptions.profile = options.profile
+	scmdOptions.installIstio = c.shouldInstallIstio
+	scmdOptions.installCertManager = c.shouldInstallCertManager
+	scmdOptions.installCanary = c.shouldInstallCanary
+	scmdOptions.installDemoapp = c.shouldInstallDemo
+	scmdOptions.demoNamespace = options.demoNamespace
+	scmdOptions.ingressOptions = options.ingressOptions
+	scmdOptions.externalEndpoints = options.externalEndpoints
+	scmdOptions.tracingStorage = options.tracingStorage
+	scmd := NewPreflightCommand(c.cli, scmdOptions)
+	err := scmd.RunE(scmd, nil)
+	if err != nil {
+		return errors.WrapIf(err, "use --skip-preflight to install anyway")
+	}
+
+	return nil
+}
+
 func (c *installCommand) runSubcommands(options *InstallOptions) error {
 	var err error
 	var scmd *cobra.Command
@@ -515,6 +685,7 @@ func (c *installCommand) runSubcommands(options *InstallOptions) error {
 
 	if c.shouldInstallCanary {
 		scmdOptions := canary.NewInstallOptions()
+		scmdOptions.PrometheusURL = options.externalEndpoints.canaryPrometheusURL()
 		if options.dumpResources {
 			scmdOptions.DumpResources = true
 		}
@@ -534,6 +705,7 @@ func (c *installCommand) runDemoInstall(options *InstallOptions) error {
 
 	if c.shouldInstallDemo {
 		scmdOptions := demoapp.NewInstallOptions()
+		scmdOptions.Namespace = options.demoNamespace
 		if options.dumpResources {
 			scmdOptions.DumpResources = true
 		}
@@ -547,6 +719,7 @@ func (c *installCommand) runDemoInstall(options *InstallOptions) error {
 	if c.shouldRunDemo {
 		scmdOptions := demoapp.NewLoadOptions()
 		scmdOptions.Nowait = true
+		scmdOptions.Namespace = options.demoNamespace
 		scmd := demoapp.NewLoadCommand(c.cli, scmdOptions)
 		err = scmd.RunE(scmd, nil)
 		if err != nil {
@@ -554,7 +727,7 @@ func (c *installCommand) runDemoInstall(options *InstallOptions) error {
 		}
 
 		dbOptions := NewDashboardOptions()
-		dbOptions.QueryParams["namespaces"] = demoapp.GetNamespace()
+		dbOptions.QueryParams["namespaces"] = options.demoNamespace
 		dbCmd := NewDashboardCommand(c.cli, dbOptions)
 		err = dbCmd.RunE(dbCmd, nil)
 		if err != nil {
@@ -566,5 +739,5 @@ func (c *installCommand) runDemoInstall(options *InstallOptions) error {
 }
 
 func shouldCertManagerBeEnabled(options *InstallOptions) bool {
-	return options.enableAuditSink
+	return options.enableAuditSink || options.ingressTLS
 }
@@ -0,0 +1,229 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	"istio.io/operator/pkg/object"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+const (
+	remoteServiceAccountName = "istio-operator-remote"
+	multiClusterSecretLabel  = "istio/multiCluster"
+)
+
+var invalidClusterNameChars = regexp.MustCompile("[^a-z0-9-]+")
+
+type clusterListCommand struct {
+	cli cli.CLI
+}
+
+// RemoteCluster is a cluster attached to the mesh
+type RemoteCluster struct {
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`
+	Gateways []string `json:"gateways,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+func NewClusterCommand(cli cli.CLI) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Attach and detach remote clusters to build a multi-cluster mesh",
+		Long: `Attach and detach remote clusters to build a multi-cluster mesh.
+
+The remote clusters share the control plane of the current cluster, which is
+managed by the Istio operator through RemoteIstio custom resources.`,
+	}
+
+	cmd.AddCommand(
+		NewClusterAttachCommand(cli, &ClusterAttachOptions{}),
+		NewClusterDetachCommand(cli, &ClusterDetachOptions{}),
+		NewClusterListCommand(cli),
+	)
+
+	return cmd
+}
+
+func NewClusterListCommand(cli cli.CLI) *cobra.Command {
+	c := &clusterListCommand{
+		cli: cli,
+	}
+
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Short:   "List the remote clusters attached to the mesh and their sync status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return c.run()
+		},
+	}
+}
+
+func (c *clusterListCommand) run() error {
+	cl, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	var remoteIstios v1beta1.RemoteIstioList
+	err = cl.List(context.Background(), &remoteIstios, client.InNamespace(IstioNamespace))
+	if err != nil {
+		return errors.WrapIf(err, "could not list remote Istio resources")
+	}
+
+	clusters := make([]RemoteCluster, len(remoteIstios.Items))
+	for i, remoteIstio := range remoteIstios.Items {
+		status := string(remoteIstio.Status.Status)
+		if status == "" {
+			status = "Pending"
+		}
+		clusters[i] = RemoteCluster{
+			Name:     remoteIstio.Name,
+			Status:   status,
+			Gateways: remoteIstio.Status.GatewayAddress,
+			Error:    remoteIstio.Status.ErrorMessage,
+		}
+	}
+
+	ctx := &output.Context{
+		Out:     c.cli.Out(),
+		Color:   c.cli.Color(),
+		Format:  c.cli.OutputFormat(),
+		Fields:  []string{"Name", "Status", "Gateways", "Error"},
+		Headers: []string{"Name", "Status", "Gateways", "Error"},
+	}
+
+	err = output.Output(ctx, clusters)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
+
+// clusterNameFromContext derives a valid resource name from the name of a kubeconfig context
+func clusterNameFromContext(context string) string {
+	return strings.Trim(invalidClusterNameChars.ReplaceAllString(strings.ToLower(context), "-"), "-")
+}
+
+// getRemoteAccessObjects returns the resources which let the operator manage the remote cluster
+// with the same permissions it has in the primary cluster
+func getRemoteAccessObjects() (object.K8sObjects, error) {
+	rules, err := getOperatorRules()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []runtime.Object{
+		&corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: IstioNamespace,
+			},
+		},
+		&corev1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      remoteServiceAccountName,
+				Namespace: IstioNamespace,
+			},
+		},
+		&rbacv1.ClusterRole{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: remoteServiceAccountName,
+			},
+			Rules: rules,
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: remoteServiceAccountName,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "ClusterRole",
+				Name:     remoteServiceAccountName,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      "ServiceAccount",
+					Name:      remoteServiceAccountName,
+					Namespace: IstioNamespace,
+				},
+			},
+		},
+	}
+
+	return toK8sObjects(resources...)
+}
+
+// getOperatorRules returns the rules of the ClusterRole of the operator from its chart
+func getOperatorRules() ([]rbacv1.PolicyRule, error) {
+	objects, err := GetIstioOperatorObjects("istio-operator")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objects {
+		u := obj.UnstructuredObject()
+		if obj.Kind != "ClusterRole" || u.GetLabels()["app.kubernetes.io/component"] != "operator" {
+			continue
+		}
+
+		var role rbacv1.ClusterRole
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &role)
+		if err != nil {
+			return nil, errors.WrapIf(err, "could not convert operator cluster role")
+		}
+
+		return role.Rules, nil
+	}
+
+	return nil, errors.New("could not find the cluster role of the operator")
+}
+
+func toK8sObjects(resources ...runtime.Object) (object.K8sObjects, error) {
+	objects := make(object.K8sObjects, len(resources))
+	for i, resource := range resources {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(resource)
+		if err != nil {
+			return nil, errors.WrapIf(err, "could not convert resource to unstructured")
+		}
+		objects[i] = object.NewK8sObject(&unstructured.Unstructured{Object: u}, nil, nil)
+	}
+
+	return objects, nil
+}
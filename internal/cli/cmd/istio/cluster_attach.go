@@ -0,0 +1,259 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"emperror.dev/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+type clusterAttachCommand struct {
+	cli cli.CLI
+}
+
+type ClusterAttachOptions struct {
+	remoteContext           string
+	name                    string
+	autoInjectionNamespaces []string
+}
+
+func NewClusterAttachCommand(cli cli.CLI, options *ClusterAttachOptions) *cobra.Command {
+	c := &clusterAttachCommand{
+		cli: cli,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "attach --remote-context remote [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Attach a remote cluster to the mesh",
+		Long: `Attach a remote cluster to the mesh.
+
+The command creates a service account with the RBAC resources the Istio operator
+needs on the remote cluster, stores a kubeconfig for it in a secret of the current
+cluster and creates a RemoteIstio resource, then waits for the remote cluster to
+be in sync with the control plane.
+
+The pod and service networks of the clusters must be routable from each other.
+The primary cluster is the one of the global --context flag.`,
+		Example: `  # Attach the cluster of the remote context.
+  backyards istio cluster attach --remote-context remote
+
+  # Attach with sidecar auto injection enabled in a namespace.
+  backyards istio cluster attach --remote-context remote --auto-injection-namespaces default`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if options.name == "" {
+				options.name = clusterNameFromContext(options.remoteContext)
+			}
+
+			return c.run(options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.remoteContext, "remote-context", options.remoteContext, "Name of the kubeconfig context of the remote cluster")
+	cmd.Flags().StringVar(&options.name, "name", options.name, "Name of the remote cluster in the mesh (defaults to the name of the context)")
+	cmd.Flags().StringSliceVar(&options.autoInjectionNamespaces, "auto-injection-namespaces", options.autoInjectionNamespaces, "Namespaces of the remote cluster to enable sidecar auto injection in")
+	_ = cmd.MarkFlagRequired("remote-context")
+
+	return cmd
+}
+
+func (c *clusterAttachCommand) run(options *ClusterAttachOptions) error {
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	remoteConfig, err := k8sclient.GetConfigWithContext(viper.GetString("kubeconfig"), options.remoteContext)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not get k8s config", "context", options.remoteContext)
+	}
+	if remoteConfig.Host == config.Host {
+		return errors.NewWithDetails("the remote cluster must be different from the current one", "context", options.remoteContext)
+	}
+
+	remoteClient, err := k8sclient.NewClient(remoteConfig, k8sclient.Options{})
+	if err != nil {
+		return errors.WrapIf(err, "could not get k8s client for the remote cluster")
+	}
+
+	log.Infof("creating the RBAC resources of the Istio operator on cluster %s", options.name)
+	remoteObjects, err := getRemoteAccessObjects()
+	if err != nil {
+		return err
+	}
+	err = k8s.ApplyResources(remoteClient, c.cli.LabelManager(), c.cli.Logger(), remoteObjects)
+	if err != nil {
+		return errors.WrapIf(err, "could not apply k8s resources on the remote cluster")
+	}
+
+	kubeconfig, err := c.getRemoteKubeconfig(remoteClient, remoteConfig, options.name)
+	if err != nil {
+		return err
+	}
+
+	objects, err := toK8sObjects(
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      options.name,
+				Namespace: IstioNamespace,
+				Labels: map[string]string{
+					multiClusterSecretLabel: "true",
+				},
+			},
+			Data: map[string][]byte{
+				options.name: kubeconfig,
+			},
+		},
+		&v1beta1.RemoteIstio{
+			TypeMeta: metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "RemoteIstio"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      options.name,
+				Namespace: IstioNamespace,
+			},
+			Spec: getRemoteIstioSpec(options),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	cl, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	err = k8s.ApplyResources(cl, c.cli.LabelManager(), c.cli.Logger(), objects)
+	if err != nil {
+		return errors.WrapIf(err, "could not apply k8s resources")
+	}
+
+	err = k8s.WaitForResourcesConditions(config, k8s.NamesWithGVKFromK8sObjects(objects, "RemoteIstio"), c.cli.WaitOptions(), k8s.ExistsConditionCheck, remoteIstioAvailableConditionCheck)
+	if err != nil {
+		return errors.WrapIf(err, "remote cluster did not get in sync, check its status with 'backyards istio cluster list'")
+	}
+
+	log.Infof("cluster %s attached to the mesh successfully", options.name)
+
+	return nil
+}
+
+// getRemoteKubeconfig returns a kubeconfig for the remote cluster authenticating with the token of the operator service account
+func (c *clusterAttachCommand) getRemoteKubeconfig(remoteClient k8sclient.Client, remoteConfig *rest.Config, name string) ([]byte, error) {
+	serviceAccount := k8s.NamespacedNameWithGVK{
+		NamespacedName: types.NamespacedName{
+			Name:      remoteServiceAccountName,
+			Namespace: IstioNamespace,
+		},
+		GroupVersionKind: corev1.SchemeGroupVersion.WithKind("ServiceAccount"),
+	}
+	err := k8s.WaitForResourcesConditions(remoteConfig, []k8s.NamespacedNameWithGVK{serviceAccount}, c.cli.WaitOptions(), k8s.ExistsConditionCheck, serviceAccountTokenConditionCheck)
+	if err != nil {
+		return nil, errors.WrapIf(err, "service account token was not created on the remote cluster")
+	}
+
+	secret, err := k8s.GetSecretForServiceAccountName(remoteClient, serviceAccount.NamespacedName)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   remoteConfig.Host,
+		CertificateAuthorityData: secret.Data[corev1.ServiceAccountRootCAKey],
+	}
+	kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{
+		Token: string(secret.Data[corev1.ServiceAccountTokenKey]),
+	}
+	kubeconfig.Contexts[name] = &clientcmdapi.Context{
+		Cluster:  name,
+		AuthInfo: name,
+	}
+	kubeconfig.CurrentContext = name
+
+	raw, err := clientcmd.Write(*kubeconfig)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not marshal kubeconfig")
+	}
+
+	return raw, nil
+}
+
+func getRemoteIstioSpec(options *ClusterAttachOptions) v1beta1.RemoteIstioSpec {
+	enabled := true
+
+	return v1beta1.RemoteIstioSpec{
+		AutoInjectionNamespaces: options.autoInjectionNamespaces,
+		IncludeIPRanges:         "*",
+		EnabledServices: []v1beta1.IstioService{
+			{
+				Name:          "istio-pilot",
+				LabelSelector: "istio=pilot",
+			},
+			{
+				Name:          "istio-policy",
+				LabelSelector: "istio-mixer-type=policy",
+			},
+			{
+				Name:          "istio-telemetry",
+				LabelSelector: "istio-mixer-type=telemetry",
+			},
+		},
+		Citadel: v1beta1.CitadelConfiguration{
+			Enabled: &enabled,
+		},
+		SidecarInjector: v1beta1.SidecarInjectorConfiguration{
+			Enabled:      &enabled,
+			ReplicaCount: 1,
+		},
+	}
+}
+
+func serviceAccountTokenConditionCheck(obj *unstructured.Unstructured, k8serror error) bool {
+	if k8serror != nil {
+		return false
+	}
+
+	secrets, _, _ := unstructured.NestedSlice(obj.Object, "secrets")
+
+	return len(secrets) > 0
+}
+
+func remoteIstioAvailableConditionCheck(obj *unstructured.Unstructured, k8serror error) bool {
+	if k8serror != nil {
+		return false
+	}
+
+	status, _, _ := unstructured.NestedString(obj.Object, "status", "Status")
+
+	return status == string(v1beta1.Available)
+}
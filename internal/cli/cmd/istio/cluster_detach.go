@@ -0,0 +1,151 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"emperror.dev/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"istio.io/operator/pkg/object"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+type clusterDetachCommand struct {
+	cli cli.CLI
+}
+
+type ClusterDetachOptions struct {
+	remoteContext string
+	name          string
+}
+
+func NewClusterDetachCommand(cli cli.CLI, options *ClusterDetachOptions) *cobra.Command {
+	c := &clusterDetachCommand{
+		cli: cli,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "detach [name] [flags]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Detach a remote cluster from the mesh",
+		Long: `Detach a remote cluster from the mesh.
+
+The command deletes the RemoteIstio resource, which makes the Istio operator remove
+the Istio components from the remote cluster, and the kubeconfig secret of the
+remote cluster. If the context of the remote cluster is given, the RBAC resources
+created on attach are removed from the remote cluster as well. The primary cluster
+is the one of the global --context flag.`,
+		Example: `  # Detach the cluster of the remote context.
+  backyards istio cluster detach --remote-context remote
+
+  # Detach a cluster by name, without cleaning up the remote cluster.
+  backyards istio cluster detach remote`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if len(args) > 0 {
+				options.name = args[0]
+			}
+			if options.name == "" {
+				if options.remoteContext == "" {
+					return errors.New("either the name or the --remote-context of the remote cluster must be specified")
+				}
+				options.name = clusterNameFromContext(options.remoteContext)
+			}
+
+			return c.run(options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.remoteContext, "remote-context", options.remoteContext, "Name of the kubeconfig context of the remote cluster to clean up")
+
+	return cmd
+}
+
+func (c *clusterDetachCommand) run(options *ClusterDetachOptions) error {
+	cl, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	// the RemoteIstio goes first, as the operator needs the kubeconfig secret to clean up the remote cluster
+	objects, err := toK8sObjects(
+		&v1beta1.RemoteIstio{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "RemoteIstio"},
+			ObjectMeta: metav1.ObjectMeta{Name: options.name, Namespace: IstioNamespace},
+		},
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: options.name, Namespace: IstioNamespace},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = k8s.DeleteResources(cl, c.cli.LabelManager(), c.cli.Logger(), objects, k8s.WaitForResourceConditions(config, c.cli.WaitOptions(), k8s.NonExistsConditionCheck))
+	if err != nil {
+		return errors.WrapIf(err, "could not delete k8s resources")
+	}
+
+	if options.remoteContext != "" {
+		remoteConfig, err := k8sclient.GetConfigWithContext(viper.GetString("kubeconfig"), options.remoteContext)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not get k8s config", "context", options.remoteContext)
+		}
+
+		remoteClient, err := k8sclient.NewClient(remoteConfig, k8sclient.Options{})
+		if err != nil {
+			return errors.WrapIf(err, "could not get k8s client for the remote cluster")
+		}
+
+		remoteObjects, err := getRemoteAccessObjects()
+		if err != nil {
+			return err
+		}
+
+		// the namespace may hold other resources, only the RBAC resources are removed
+		rbacObjects := make(object.K8sObjects, 0, len(remoteObjects))
+		for _, obj := range remoteObjects {
+			if obj.Kind != "Namespace" {
+				rbacObjects = append(rbacObjects, obj)
+			}
+		}
+
+		err = k8s.DeleteResources(remoteClient, c.cli.LabelManager(), c.cli.Logger(), rbacObjects, k8s.WaitForResourceConditions(remoteConfig, c.cli.WaitOptions(), k8s.NonExistsConditionCheck))
+		if err != nil {
+			return errors.WrapIf(err, "could not delete k8s resources on the remote cluster")
+		}
+	} else {
+		log.Warnf("the RBAC resources of the Istio operator are kept on the remote cluster, use the --remote-context flag to remove them")
+	}
+
+	log.Infof("cluster %s detached from the mesh successfully", options.name)
+
+	return nil
+}
@@ -36,6 +36,9 @@ func NewRootCmd(cli cli.CLI) *cobra.Command {
 	cmd.AddCommand(
 		NewInstallCommand(cli, NewInstallOptions()),
 		NewUninstallCommand(cli, NewUninstallOptions()),
+		NewConfigCommand(cli),
+		NewUpgradeCommand(cli, NewUpgradeOptions()),
+		NewClusterCommand(cli),
 	)
 
 	cmd.PersistentFlags().StringVarP(&IstioNamespace, "namespace", "n", DefaultNamespace, "Namespace in which Istio is installed [$ISTIO_NAMESPACE]")
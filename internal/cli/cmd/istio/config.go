@@ -0,0 +1,199 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/formatting"
+	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+const istioCRDName = "istios.istio.banzaicloud.io"
+
+type ConfigChangeOptions struct {
+	dryRun bool
+	yes    bool
+}
+
+func NewConfigCommand(cli cli.CLI) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Get and modify the configuration of the Istio control plane",
+		Long: `Get and modify the configuration of the Istio control plane.
+
+The commands work on the spec of the Istio custom resource, keys are dot separated
+paths relative to it, like 'mtls' or 'outboundTrafficPolicy.mode'.`,
+	}
+
+	cmd.AddCommand(
+		NewConfigGetCommand(cli),
+		NewConfigSetCommand(cli, &ConfigChangeOptions{}),
+		NewConfigUnsetCommand(cli, &ConfigChangeOptions{}),
+	)
+
+	return cmd
+}
+
+func addConfigChangeFlags(cmd *cobra.Command, options *ConfigChangeOptions) {
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", options.dryRun, "Only show the difference without applying it")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", options.yes, "Apply the change without asking for confirmation, required when not running interactively")
+}
+
+// parseConfigKey splits a dot separated key into the path of the field in the Istio CR
+func parseConfigKey(key string) ([]string, error) {
+	path := strings.Split(strings.TrimPrefix(key, "spec."), ".")
+	for _, segment := range path {
+		if segment == "" {
+			return nil, errors.NewWithDetails("invalid key", "key", key)
+		}
+	}
+
+	return append([]string{"spec"}, path...), nil
+}
+
+// istioConfig is the live Istio CR along with the validation schema of its CRD
+type istioConfig struct {
+	cli    cli.CLI
+	cr     *unstructured.Unstructured
+	schema *apiextensionsv1beta1.JSONSchemaProps
+}
+
+func getIstioConfig(cli cli.CLI) (*istioConfig, error) {
+	cl, err := cli.GetK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cr := &unstructured.Unstructured{}
+	cr.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("Istio"))
+	err = cl.Get(context.Background(), types.NamespacedName{
+		Name:      IstioCRName,
+		Namespace: IstioNamespace,
+	}, cr)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, errors.Errorf("could not find Istio CR '%s' in '%s' namespace, install Istio first", IstioCRName, IstioNamespace)
+		}
+		return nil, errors.WrapIf(err, "could not get Istio CR")
+	}
+
+	var crd apiextensionsv1beta1.CustomResourceDefinition
+	err = cl.Get(context.Background(), types.NamespacedName{Name: istioCRDName}, &crd)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not get CRD", "name", istioCRDName)
+	}
+
+	var schema *apiextensionsv1beta1.JSONSchemaProps
+	if crd.Spec.Validation != nil {
+		schema = crd.Spec.Validation.OpenAPIV3Schema
+	}
+	if schema == nil {
+		log.Warnf("CRD %s has no validation schema, changes are not validated", istioCRDName)
+	}
+
+	return &istioConfig{
+		cli:    cli,
+		cr:     cr,
+		schema: schema,
+	}, nil
+}
+
+// fieldSchema returns the schema of the field at the given path, or nil if it is unknown
+func (c *istioConfig) fieldSchema(path []string) *apiextensionsv1beta1.JSONSchemaProps {
+	return k8s.SchemaForPath(c.schema, path)
+}
+
+// apply validates the modified Istio CR, shows the difference and updates the live object once confirmed
+func (c *istioConfig) apply(modified *unstructured.Unstructured, options *ConfigChangeOptions) error {
+	changed, err := c.confirm(modified, options)
+	if err != nil || !changed || options.dryRun {
+		return err
+	}
+
+	return c.update(modified)
+}
+
+// confirm validates the modified Istio CR, shows the difference and asks for confirmation if needed
+func (c *istioConfig) confirm(modified *unstructured.Unstructured, options *ConfigChangeOptions) (bool, error) {
+	err := k8s.ValidateWithSchema(modified.Object, c.schema)
+	if err != nil {
+		return false, errors.WrapIf(err, "invalid Istio configuration")
+	}
+
+	current, err := yaml.Marshal(c.cr.Object["spec"])
+	if err != nil {
+		return false, errors.WrapIf(err, "could not marshal Istio CR spec")
+	}
+	desired, err := yaml.Marshal(modified.Object["spec"])
+	if err != nil {
+		return false, errors.WrapIf(err, "could not marshal Istio CR spec")
+	}
+
+	if string(current) == string(desired) {
+		log.Info("the Istio configuration is already up to date")
+		return false, nil
+	}
+
+	fmt.Fprint(c.cli.Out(), formatting.Diff(string(current), string(desired), c.cli.Color()))
+
+	if options.dryRun || options.yes {
+		return true, nil
+	}
+	if !c.cli.InteractiveTerminal() {
+		return false, errors.New("the changes cannot be confirmed interactively, use the --yes flag to apply them")
+	}
+
+	confirmed := false
+	err = survey.AskOne(&survey.Confirm{Message: "Do you want to apply the changes?"}, &confirmed)
+	if err != nil {
+		return false, errors.WrapIf(err, "could not ask for confirmation")
+	}
+	if !confirmed {
+		return false, errors.New("change cancelled")
+	}
+
+	return true, nil
+}
+
+func (c *istioConfig) update(modified *unstructured.Unstructured) error {
+	cl, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	err = cl.Update(context.Background(), modified)
+	if err != nil {
+		return errors.WrapIf(err, "could not update Istio CR")
+	}
+
+	log.Info("Istio configuration updated successfully")
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+)
+
+type configGetCommand struct {
+	cli cli.CLI
+}
+
+func NewConfigGetCommand(cli cli.CLI) *cobra.Command {
+	c := &configGetCommand{
+		cli: cli,
+	}
+
+	return &cobra.Command{
+		Use:   "get [key.path]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Show the configuration of the Istio control plane",
+		Example: `  # Show the whole configuration.
+  backyards istio config get
+
+  # Show the outbound traffic policy.
+  backyards istio config get outboundTrafficPolicy.mode`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			key := "spec"
+			if len(args) > 0 {
+				key = args[0]
+			}
+
+			return c.run(key)
+		},
+	}
+}
+
+func (c *configGetCommand) run(key string) error {
+	path := []string{"spec"}
+	if key != "spec" {
+		var err error
+		path, err = parseConfigKey(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	config, err := getIstioConfig(c.cli)
+	if err != nil {
+		return err
+	}
+
+	value, found, err := unstructured.NestedFieldNoCopy(config.cr.Object, path...)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not get value", "key", key)
+	}
+	if !found {
+		return errors.NewWithDetails("key is not set", "key", key)
+	}
+
+	var raw []byte
+	if c.cli.OutputFormat() == output.OutputFormatJSON {
+		raw, err = json.MarshalIndent(value, "", "  ")
+		raw = append(raw, '\n')
+	} else {
+		raw, err = yaml.Marshal(value)
+	}
+	if err != nil {
+		return errors.WrapIf(err, "could not marshal value")
+	}
+
+	fmt.Fprint(c.cli.Out(), string(raw))
+
+	return nil
+}
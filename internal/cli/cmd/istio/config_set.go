@@ -0,0 +1,116 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+)
+
+type configSetCommand struct {
+	cli cli.CLI
+}
+
+func NewConfigSetCommand(cli cli.CLI, options *ConfigChangeOptions) *cobra.Command {
+	c := &configSetCommand{
+		cli: cli,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "set key.path=value [key.path=value...]",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Set configuration values of the Istio control plane",
+		Long: `Set configuration values of the Istio control plane.
+
+Values are parsed as YAML unless the field is a string, so lists and objects can be
+set as well. The modified Istio CR is validated against the schema of its CRD and
+the difference is shown before applying it.`,
+		Example: `  # Enable mTLS.
+  backyards istio config set mtls=true
+
+  # Only allow outbound traffic to registered services.
+  backyards istio config set outboundTrafficPolicy.mode=REGISTRY_ONLY
+
+  # Set the resources of the sidecars.
+  backyards istio config set 'proxy.resources={requests: {cpu: 50m, memory: 64Mi}}'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return c.run(args, options)
+		},
+	}
+
+	addConfigChangeFlags(cmd, options)
+
+	return cmd
+}
+
+func (c *configSetCommand) run(args []string, options *ConfigChangeOptions) error {
+	config, err := getIstioConfig(c.cli)
+	if err != nil {
+		return err
+	}
+
+	modified := config.cr.DeepCopy()
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return errors.NewWithDetails("invalid argument, must be in key.path=value form", "argument", arg)
+		}
+
+		path, err := parseConfigKey(parts[0])
+		if err != nil {
+			return err
+		}
+
+		value, err := config.parseValue(path, parts[1])
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "invalid value", "key", parts[0])
+		}
+
+		err = unstructured.SetNestedField(modified.Object, value, path...)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not set value", "key", parts[0])
+		}
+	}
+
+	return config.apply(modified, options)
+}
+
+// parseValue converts the raw value to the type the schema of the field requires
+func (c *istioConfig) parseValue(path []string, raw string) (interface{}, error) {
+	if schema := c.fieldSchema(path); schema != nil && schema.Type == "string" {
+		return raw, nil
+	}
+
+	var value interface{}
+	err := yaml.Unmarshal([]byte(raw), &value)
+	if err != nil {
+		return nil, err
+	}
+
+	if number, ok := value.(float64); ok && number == float64(int64(number)) {
+		return int64(number), nil
+	}
+
+	return value, nil
+}
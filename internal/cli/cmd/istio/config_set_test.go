@@ -0,0 +1,98 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"reflect"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+)
+
+func TestParseValue(t *testing.T) {
+	tests := map[string]struct {
+		path     []string
+		raw      string
+		expected interface{}
+	}{
+		"string field": {
+			path:     []string{"spec", "version"},
+			raw:      "1.4",
+			expected: "1.4",
+		},
+		"integer": {
+			path:     []string{"spec", "replicas"},
+			raw:      "3",
+			expected: int64(3),
+		},
+		"whole float": {
+			path:     []string{"spec", "ratio"},
+			raw:      "2.0",
+			expected: int64(2),
+		},
+		"float": {
+			path:     []string{"spec", "ratio"},
+			raw:      "0.5",
+			expected: 0.5,
+		},
+		"boolean": {
+			path:     []string{"spec", "mtls"},
+			raw:      "true",
+			expected: true,
+		},
+		"unknown field": {
+			path:     []string{"spec", "mtsl"},
+			raw:      "true",
+			expected: true,
+		},
+		"object": {
+			path:     []string{"spec", "mtls"},
+			raw:      "{enabled: true}",
+			expected: map[string]interface{}{"enabled": true},
+		},
+	}
+
+	config := &istioConfig{
+		schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"version":  {Type: "string"},
+						"replicas": {Type: "integer"},
+						"ratio":    {Type: "number"},
+						"mtls":     {Type: "boolean"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			got, err := config.parseValue(test.path, test.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("unexpected value\ngot : %#v\nwant: %#v", got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+)
+
+type configUnsetCommand struct {
+	cli cli.CLI
+}
+
+func NewConfigUnsetCommand(cli cli.CLI, options *ConfigChangeOptions) *cobra.Command {
+	c := &configUnsetCommand{
+		cli: cli,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "unset key.path [key.path...]",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Unset configuration values of the Istio control plane",
+		Long: `Unset configuration values of the Istio control plane.
+
+Unset fields fall back to the defaults of the Istio operator. The difference is
+shown before applying the change.`,
+		Example: `  # Fall back to the default outbound traffic policy.
+  backyards istio config unset outboundTrafficPolicy`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return c.run(args, options)
+		},
+	}
+
+	addConfigChangeFlags(cmd, options)
+
+	return cmd
+}
+
+func (c *configUnsetCommand) run(keys []string, options *ConfigChangeOptions) error {
+	config, err := getIstioConfig(c.cli)
+	if err != nil {
+		return err
+	}
+
+	modified := config.cr.DeepCopy()
+	for _, key := range keys {
+		path, err := parseConfigKey(key)
+		if err != nil {
+			return err
+		}
+
+		_, found, err := unstructured.NestedFieldNoCopy(modified.Object, path...)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not get value", "key", key)
+		}
+		if !found {
+			return errors.NewWithDetails("key is not set", "key", key)
+		}
+
+		unstructured.RemoveNestedField(modified.Object, path...)
+	}
+
+	return config.apply(modified, options)
+}
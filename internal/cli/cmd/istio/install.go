@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"time"
 
 	"emperror.dev/errors"
 	"github.com/spf13/cobra"
@@ -28,12 +27,13 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/yaml"
 
 	"github.com/banzaicloud/backyards-cli/cmd/backyards/static/istio_assets"
 	"github.com/banzaicloud/backyards-cli/cmd/backyards/static/istio_operator"
+	cliutil "github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 	"github.com/banzaicloud/backyards-cli/pkg/helm"
 	"github.com/banzaicloud/backyards-cli/pkg/k8s"
@@ -44,13 +44,26 @@ import (
 const (
 	IstioCRName         = "mesh"
 	istioCRYamlFilename = "istio.yaml"
+	istioImageHub       = "docker.io/istio"
 )
 
 var (
 	istioCRDs = []string{
-		"istios.istio.banzaicloud.io",
+		istioCRDName,
 		"remoteistios.istio.banzaicloud.io",
 	}
+
+	// istioComponentImages are the images of the components enabled by default which the operator
+	// would otherwise choose itself, keyed by the field of the component in the Istio CR spec
+	istioComponentImages = map[string]string{
+		"citadel":         "citadel",
+		"galley":          "galley",
+		"pilot":           "pilot",
+		"mixer":           "mixer",
+		"sidecarInjector": "sidecar_injector",
+		"proxy":           "proxyv2",
+		"proxyInit":       "proxy_init",
+	}
 )
 
 type installCommand struct {
@@ -106,13 +119,13 @@ The installer automatically detects whether the CRDs are installed or not, and b
 }
 
 func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
-	objects, err := getIstioOperatorObjects(options.releaseName)
+	objects, err := GetIstioOperatorObjects(options.releaseName)
 	if err != nil {
 		return err
 	}
 	objects.Sort(helm.InstallObjectOrder())
 
-	istioCRObj, err := getIstioCR(options.istioCRFilename)
+	istioCRObj, err := GetIstioCR(options.istioCRFilename)
 	if err != nil {
 		return err
 	}
@@ -144,7 +157,7 @@ func (c *installCommand) run(cli cli.CLI, options *InstallOptions) error {
 			if err != nil {
 				return errors.WrapIf(err, "could not render YAML manifest")
 			}
-			fmt.Fprintln(os.Stderr, "The same command should be run after the CRDs are installed successfully to install the rest of the resources.")
+			cli.Logger().Info("the same command should be run after the CRDs are installed successfully to install the rest of the resources")
 			fmt.Fprint(cli.Out(), yaml)
 		} else {
 			yaml, err := objs.YAMLManifest()
@@ -164,20 +177,20 @@ func (c *installCommand) applyResources(crds, objects object.K8sObjects) error {
 		return err
 	}
 
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
 	// apply CRDs first
-	err = k8s.ApplyResources(client, c.cli.LabelManager(), crds)
+	err = k8s.ApplyResources(client, c.cli.LabelManager(), c.cli.Logger(), crds)
 	if err != nil {
 		return errors.WrapIf(err, "could not apply k8s resources")
 	}
 
-	backoff := wait.Backoff{
-		Duration: time.Second * 5,
-		Factor:   1,
-		Jitter:   0,
-		Steps:    25,
-	}
+	waitOptions := c.cli.WaitOptions()
 
-	err = k8s.WaitForResourcesConditions(client, k8s.NamesWithGVKFromK8sObjects(crds), backoff, k8s.CRDEstablishedConditionCheck)
+	err = k8s.WaitForResourcesConditions(config, k8s.NamesWithGVKFromK8sObjects(crds), waitOptions, k8s.CRDEstablishedConditionCheck)
 	if err != nil {
 		return err
 	}
@@ -189,16 +202,16 @@ func (c *installCommand) applyResources(crds, objects object.K8sObjects) error {
 	}
 
 	// apply the rest of the resources
-	err = k8s.ApplyResources(client, c.cli.LabelManager(), objects)
+	err = k8s.ApplyResources(client, c.cli.LabelManager(), c.cli.Logger(), objects)
 	if err != nil {
 		return errors.WrapIf(err, "could not apply k8s resources")
 	}
 
-	err = k8s.WaitForResourcesConditions(client, k8s.NamesWithGVKFromK8sObjects(objects, "StatefulSet"), backoff, k8s.ExistsConditionCheck, k8s.ReadyReplicasConditionCheck)
+	err = k8s.WaitForResourcesConditions(config, k8s.NamesWithGVKFromK8sObjects(objects, "StatefulSet"), waitOptions, k8s.ExistsConditionCheck, k8s.ReadyConditionCheck)
 	if err != nil {
 		return err
 	}
-	err = k8s.WaitForResourcesConditions(client, c.getIstioDeploymentsToWaitFor(), backoff, k8s.ExistsConditionCheck, k8s.ReadyReplicasConditionCheck)
+	err = k8s.WaitForResourcesConditions(config, c.getIstioDeploymentsToWaitFor(), waitOptions, k8s.ExistsConditionCheck, k8s.ReadyConditionCheck)
 	if err != nil {
 		return err
 	}
@@ -218,6 +231,24 @@ func (c *installCommand) getIstioDeploymentsToWaitFor() []k8s.NamespacedNameWith
 		panic(err)
 	}
 
+	deploymentNames := ControlPlaneDeploymentNames(&istioCR)
+
+	deployments := make([]k8s.NamespacedNameWithGVK, len(deploymentNames))
+	for i, name := range deploymentNames {
+		deployments[i] = k8s.NamespacedNameWithGVK{
+			NamespacedName: types.NamespacedName{
+				Name:      name,
+				Namespace: IstioNamespace,
+			},
+			GroupVersionKind: appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		}
+	}
+
+	return deployments
+}
+
+// ControlPlaneDeploymentNames returns the names of the deployments managed by the operator for the given Istio CR
+func ControlPlaneDeploymentNames(istioCR *v1beta1.Istio) []string {
 	deploymentNames := make([]string, 0)
 
 	if util.PointerToBool(istioCR.Spec.Citadel.Enabled) {
@@ -245,21 +276,10 @@ func (c *installCommand) getIstioDeploymentsToWaitFor() []k8s.NamespacedNameWith
 		}
 	}
 
-	deployments := make([]k8s.NamespacedNameWithGVK, len(deploymentNames))
-	for i, name := range deploymentNames {
-		deployments[i] = k8s.NamespacedNameWithGVK{
-			NamespacedName: types.NamespacedName{
-				Name:      name,
-				Namespace: IstioNamespace,
-			},
-			GroupVersionKind: appsv1.SchemeGroupVersion.WithKind("Deployment"),
-		}
-	}
-
-	return deployments
+	return deploymentNames
 }
 
-func getIstioOperatorObjects(releaseName string) (object.K8sObjects, error) {
+func GetIstioOperatorObjects(releaseName string) (object.K8sObjects, error) {
 	var values Values
 
 	valuesYAML, err := helm.GetDefaultValues(istio_operator.Chart)
@@ -289,10 +309,10 @@ func getIstioOperatorObjects(releaseName string) (object.K8sObjects, error) {
 		return nil, errors.WrapIf(err, "could not render helm manifest objects")
 	}
 
-	return objects, nil
+	return cliutil.RewriteImages(objects), nil
 }
 
-func getIstioCR(filename string) (*object.K8sObject, error) {
+func GetIstioCR(filename string) (*object.K8sObject, error) {
 	var err error
 	var istioCRFile http.File
 	if filename != "" {
@@ -320,7 +340,36 @@ func getIstioCR(filename string) (*object.K8sObject, error) {
 	metadata["namespace"] = IstioNamespace
 	metadata["name"] = IstioCRName
 
-	return obj, nil
+	err = setDefaultImages(obj.UnstructuredObject())
+	if err != nil {
+		return nil, err
+	}
+
+	// rebuild the object as the parsed YAML is cached
+	obj = object.NewK8sObject(obj.UnstructuredObject(), nil, nil)
+
+	return cliutil.RewriteImages(object.K8sObjects{obj})[0], nil
+}
+
+// setDefaultImages makes the images of the components explicit so they can be listed and overridden
+func setDefaultImages(istioCR *unstructured.Unstructured) error {
+	version, _, err := unstructured.NestedString(istioCR.Object, "spec", "version")
+	if err != nil || version == "" {
+		return errors.WrapIf(err, "could not get Istio version from the Istio CR")
+	}
+
+	for component, name := range istioComponentImages {
+		image, _, _ := unstructured.NestedString(istioCR.Object, "spec", component, "image")
+		if image != "" {
+			continue
+		}
+		err = unstructured.SetNestedField(istioCR.Object, fmt.Sprintf("%s/%s:%s", istioImageHub, name, version), "spec", component, "image")
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not set default image", "component", component)
+		}
+	}
+
+	return nil
 }
 
 func (c *installCommand) isCRDsExists(crdNames []string) (bool, error) {
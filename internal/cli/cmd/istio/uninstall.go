@@ -15,29 +15,58 @@
 package istio
 
 import (
+	"context"
 	"fmt"
-	"time"
+	"sort"
 
 	"emperror.dev/errors"
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"istio.io/operator/pkg/object"
-	"k8s.io/apimachinery/pkg/util/wait"
+	corev1 "k8s.io/api/core/v1"
+	k8smeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 	"github.com/banzaicloud/backyards-cli/pkg/helm"
 	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
 )
 
+const injectionLabel = "istio-injection"
+
+// routingRuleKinds are the Istio resources which become orphaned without the control plane
+var routingRuleKinds = []schema.GroupVersionKind{
+	{Group: "networking.istio.io", Version: "v1alpha3", Kind: "VirtualService"},
+	{Group: "networking.istio.io", Version: "v1alpha3", Kind: "DestinationRule"},
+	{Group: "networking.istio.io", Version: "v1alpha3", Kind: "Gateway"},
+}
+
 type uninstallCommand struct {
 	cli cli.CLI
 }
 
 type UninstallOptions struct {
-	releaseName string
+	releaseName           string
+	removeInjectionLabels bool
+	restartWorkloads      bool
 
+	Force         bool
 	DumpResources bool
 }
 
+// UninstallImpact is a resource which still relies on the Istio control plane
+type UninstallImpact struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Impact    string `json:"impact"`
+}
+
 func NewUninstallOptions() *UninstallOptions {
 	return &UninstallOptions{}
 }
@@ -54,10 +83,20 @@ func NewUninstallCommand(cli cli.CLI, options *UninstallOptions) *cobra.Command
 		Long: `Output or delete Kubernetes resources to uninstall Istio.
 
 The command automatically removes the resources.
-It can only dump the removable resources with the '--dump-resources' option.`,
+It can only dump the removable resources with the '--dump-resources' option.
+
+Before removing anything it reports the namespaces with sidecar injection enabled,
+the workloads still running sidecars and the VirtualServices, DestinationRules and
+Gateways which would be orphaned. If there are any, the uninstall requires the
+'--force' flag or an interactive confirmation, and offers to remove the injection
+labels and, once they are removed, to restart the affected Deployments, StatefulSets
+and DaemonSets without sidecars.`,
 		Example: `  # Default uninstall.
   backyards istio uninstall
 
+  # Uninstall even if workloads still use Istio, and move them off the mesh.
+  backyards istio uninstall --force --remove-injection-labels --restart-workloads
+
   # Uninstall Istio from a non-default namespace.
   backyards istio uninstall -n custom-istio-ns`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -69,6 +108,9 @@ It can only dump the removable resources with the '--dump-resources' option.`,
 	}
 
 	cmd.Flags().StringVar(&options.releaseName, "release-name", "istio-operator", "Name of the release")
+	cmd.Flags().BoolVar(&options.Force, "force", options.Force, "Uninstall without asking for confirmation even if workloads still use Istio")
+	cmd.Flags().BoolVar(&options.removeInjectionLabels, "remove-injection-labels", options.removeInjectionLabels, "Remove the sidecar injection label from the namespaces without asking for confirmation")
+	cmd.Flags().BoolVar(&options.restartWorkloads, "restart-workloads", options.restartWorkloads, "Restart the Deployments, StatefulSets and DaemonSets with sidecars without asking for confirmation once the injection labels are removed")
 
 	cmd.Flags().BoolVarP(&options.DumpResources, "dump-resources", "d", options.DumpResources, "Dump resources to stdout instead of applying them")
 
@@ -76,13 +118,13 @@ It can only dump the removable resources with the '--dump-resources' option.`,
 }
 
 func (c *uninstallCommand) run(cli cli.CLI, options *UninstallOptions) error {
-	objects, err := getIstioOperatorObjects(options.releaseName)
+	objects, err := GetIstioOperatorObjects(options.releaseName)
 	if err != nil {
 		return err
 	}
 	objects.Sort(helm.UninstallObjectOrder())
 
-	istioCRObj, err := getIstioCR("")
+	istioCRObj, err := GetIstioCR("")
 	if err != nil {
 		return err
 	}
@@ -90,7 +132,16 @@ func (c *uninstallCommand) run(cli cli.CLI, options *UninstallOptions) error {
 	objects = append([]*object.K8sObject{istioCRObj}, objects...)
 
 	if !options.DumpResources {
-		err := c.deleteResources(objects)
+		proceed, err := c.checkImpact(options)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			log.Info("Istio uninstall aborted")
+			return nil
+		}
+
+		err = c.deleteResources(objects)
 		if err != nil {
 			return errors.WrapIf(err, "could not delete k8s resources")
 		}
@@ -112,15 +163,207 @@ func (c *uninstallCommand) deleteResources(objects object.K8sObjects) error {
 		return err
 	}
 
-	err = k8s.DeleteResources(client, c.cli.LabelManager(), objects, k8s.WaitForResourceConditions(wait.Backoff{
-		Duration: time.Second * 5,
-		Factor:   1,
-		Jitter:   0,
-		Steps:    24,
-	}, k8s.NonExistsConditionCheck))
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	err = k8s.DeleteResources(client, c.cli.LabelManager(), c.cli.Logger(), objects, k8s.WaitForResourceConditions(config, c.cli.WaitOptions(), k8s.NonExistsConditionCheck))
 	if err != nil {
 		return errors.WrapIf(err, "could not delete k8s resources")
 	}
 
 	return nil
 }
+
+// checkImpact reports the resources still relying on Istio and returns whether the uninstall may proceed
+func (c *uninstallCommand) checkImpact(options *UninstallOptions) (bool, error) {
+	cl, err := c.cli.GetK8sClient()
+	if err != nil {
+		return false, err
+	}
+
+	namespaces, err := getInjectionEnabledNamespaces(cl)
+	if err != nil {
+		return false, err
+	}
+
+	workloads, err := getSidecarWorkloads(cl, "")
+	if err != nil {
+		return false, err
+	}
+	meshWorkloads := make([]OutdatedWorkload, 0)
+	for _, workload := range workloads {
+		if workload.Namespace != IstioNamespace {
+			meshWorkloads = append(meshWorkloads, workload)
+		}
+	}
+
+	rules, err := getRoutingRules(cl)
+	if err != nil {
+		return false, err
+	}
+
+	impacts := make([]UninstallImpact, 0)
+	for _, ns := range namespaces {
+		impacts = append(impacts, UninstallImpact{
+			Kind:   "Namespace",
+			Name:   ns,
+			Impact: "sidecar injection enabled",
+		})
+	}
+	for _, workload := range meshWorkloads {
+		impacts = append(impacts, UninstallImpact{
+			Namespace: workload.Namespace,
+			Kind:      workload.Kind,
+			Name:      workload.Name,
+			Impact:    "runs sidecar " + workload.Proxy,
+		})
+	}
+	impacts = append(impacts, rules...)
+
+	if len(impacts) == 0 {
+		return true, nil
+	}
+
+	log.Warn("the following resources still rely on Istio")
+	ctx := &output.Context{
+		Out:     c.cli.Out(),
+		Color:   c.cli.Color(),
+		Format:  c.cli.OutputFormat(),
+		Fields:  []string{"Namespace", "Kind", "Name", "Impact"},
+		Headers: []string{"Namespace", "Kind", "Name", "Impact"},
+	}
+	err = output.Output(ctx, impacts)
+	if err != nil {
+		return false, errors.WrapIf(err, "could not produce output")
+	}
+
+	proceed := options.Force
+	if !proceed {
+		if !c.cli.InteractiveTerminal() {
+			return false, errors.New("Istio is still in use, use the --force flag to uninstall it anyway")
+		}
+		err = survey.AskOne(&survey.Confirm{Message: "Do you want to uninstall Istio anyway?"}, &proceed)
+		if err != nil {
+			return false, errors.WrapIf(err, "could not ask for confirmation")
+		}
+		if !proceed {
+			return false, nil
+		}
+	}
+
+	// restarted pods would get the sidecars injected again as long as their namespaces are labeled
+	labeled := len(namespaces) > 0
+	if labeled {
+		remove := options.removeInjectionLabels
+		if !remove && c.cli.InteractiveTerminal() {
+			err = survey.AskOne(&survey.Confirm{Message: fmt.Sprintf("Do you want to remove the sidecar injection label from %d namespaces?", len(namespaces))}, &remove)
+			if err != nil {
+				return false, errors.WrapIf(err, "could not ask for confirmation")
+			}
+		}
+		if remove {
+			err = removeInjectionLabels(cl, namespaces)
+			if err != nil {
+				return false, err
+			}
+			labeled = false
+		}
+	}
+
+	restartable := restartableWorkloads(meshWorkloads)
+	if len(restartable) > 0 {
+		if labeled {
+			log.Info("remove the sidecar injection labels and restart the workloads to remove their sidecars, or use the --remove-injection-labels and --restart-workloads flags")
+			return true, nil
+		}
+
+		restart := options.restartWorkloads
+		if !restart && c.cli.InteractiveTerminal() {
+			err = survey.AskOne(&survey.Confirm{Message: fmt.Sprintf("Do you want to restart %d workloads?", len(restartable))}, &restart)
+			if err != nil {
+				return false, errors.WrapIf(err, "could not ask for confirmation")
+			}
+		}
+		if restart {
+			err = restartWorkloads(c.cli, restartable)
+			if err != nil {
+				return false, err
+			}
+		} else {
+			log.Info("restart the workloads to remove their sidecars, or use the --restart-workloads flag")
+		}
+	}
+
+	return true, nil
+}
+
+// getInjectionEnabledNamespaces lists the namespaces labeled for sidecar injection
+func getInjectionEnabledNamespaces(cl client.Client) ([]string, error) {
+	var namespaces corev1.NamespaceList
+	err := cl.List(context.Background(), &namespaces, client.MatchingLabels(map[string]string{
+		injectionLabel: "enabled",
+	}))
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not list namespaces")
+	}
+
+	names := make([]string, 0)
+	for _, ns := range namespaces.Items {
+		names = append(names, ns.Name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// removeInjectionLabels removes the sidecar injection label from the given namespaces
+func removeInjectionLabels(cl client.Client, namespaces []string) error {
+	patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:null}}}`, injectionLabel))
+	for _, name := range namespaces {
+		log.Infof("removing sidecar injection label from namespace %s", name)
+		ns := &corev1.Namespace{}
+		ns.Name = name
+		err := cl.Patch(context.Background(), ns, client.ConstantPatch(types.MergePatchType, patch))
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not remove sidecar injection label", "namespace", name)
+		}
+	}
+
+	return nil
+}
+
+// getRoutingRules lists the Istio routing resources outside of the Istio namespace
+func getRoutingRules(cl client.Client) ([]UninstallImpact, error) {
+	impacts := make([]UninstallImpact, 0)
+	for _, gvk := range routingRuleKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := cl.List(context.Background(), list)
+		if k8smeta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not list routing rules", "kind", gvk.Kind)
+		}
+
+		for _, item := range list.Items {
+			if item.GetNamespace() == IstioNamespace {
+				continue
+			}
+			impacts = append(impacts, UninstallImpact{
+				Namespace: item.GetNamespace(),
+				Kind:      gvk.Kind,
+				Name:      item.GetName(),
+				Impact:    "orphaned",
+			})
+		}
+	}
+
+	sort.SliceStable(impacts, func(i, j int) bool {
+		return impacts[i].Namespace < impacts[j].Namespace
+	})
+
+	return impacts, nil
+}
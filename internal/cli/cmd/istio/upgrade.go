@@ -0,0 +1,492 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"istio.io/operator/pkg/object"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+const (
+	proxyContainerName    = "istio-proxy"
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// controlPlaneDeploymentComponents maps the deployments managed by the operator to the component in the Istio CR spec which sets their image
+var controlPlaneDeploymentComponents = map[string]string{
+	"istio-citadel":          "citadel",
+	"istio-sidecar-injector": "sidecarInjector",
+	"istio-galley":           "galley",
+	"istio-pilot":            "pilot",
+	"istio-policy":           "mixer",
+	"istio-telemetry":        "mixer",
+	"istio-ingressgateway":   "proxy",
+	"istio-egressgateway":    "proxy",
+}
+
+type upgradeCommand struct {
+	cli cli.CLI
+}
+
+type UpgradeOptions struct {
+	version          string
+	releaseName      string
+	restartWorkloads bool
+
+	change ConfigChangeOptions
+}
+
+// OutdatedWorkload is a workload whose sidecar runs a different proxy than the control plane
+type OutdatedWorkload struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Proxy     string `json:"proxy"`
+}
+
+func NewUpgradeOptions() *UpgradeOptions {
+	return &UpgradeOptions{}
+}
+
+func NewUpgradeCommand(cli cli.CLI, options *UpgradeOptions) *cobra.Command {
+	c := &upgradeCommand{
+		cli: cli,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "upgrade [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Upgrade the Istio control plane",
+		Long: `Upgrade the Istio control plane.
+
+The command updates the Istio operator and the version and images in the Istio CR,
+then waits for the control plane to become healthy with the new version.
+
+Afterwards it lists the workloads whose sidecars still run an older proxy, and
+offers a rolling restart of their Deployments, StatefulSets and DaemonSets to pick up
+the new one.`,
+		Example: `  # Show the changes of the upgrade.
+  backyards istio upgrade --to 1.3 --dry-run
+
+  # Upgrade and restart the workloads with outdated sidecars.
+  backyards istio upgrade --to 1.3 --restart-workloads`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return c.run(options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.version, "to", options.version, "Istio version to upgrade to (defaults to the version shipped with the CLI)")
+	cmd.Flags().StringVar(&options.releaseName, "release-name", "istio-operator", "Name of the release")
+	cmd.Flags().BoolVar(&options.restartWorkloads, "restart-workloads", options.restartWorkloads, "Restart the Deployments, StatefulSets and DaemonSets with outdated sidecars without asking for confirmation")
+	cmd.Flags().BoolVar(&options.change.dryRun, "dry-run", options.change.dryRun, "Only show the changes without applying them")
+	cmd.Flags().BoolVarP(&options.change.yes, "yes", "y", options.change.yes, "Upgrade without asking for confirmation, required when not running interactively")
+
+	return cmd
+}
+
+func (c *upgradeCommand) run(options *UpgradeOptions) error {
+	targetCR, err := GetIstioCR("")
+	if err != nil {
+		return err
+	}
+	target := targetCR.UnstructuredObject()
+	targetVersion, _, _ := unstructured.NestedString(target.Object, "spec", "version")
+
+	if options.version != "" && minorVersion(options.version) != minorVersion(targetVersion) {
+		return errors.NewWithDetails("this version of the CLI can only upgrade to Istio "+minorVersion(targetVersion), "version", options.version)
+	}
+
+	config, err := getIstioConfig(c.cli)
+	if err != nil {
+		return err
+	}
+	currentVersion, _, _ := unstructured.NestedString(config.cr.Object, "spec", "version")
+
+	if compareMinorVersions(currentVersion, targetVersion) > 0 {
+		return errors.NewWithDetails("downgrading Istio is not supported", "current", currentVersion, "target", targetVersion)
+	}
+	if compareMinorVersions(currentVersion, targetVersion) < -1 {
+		log.Warnf("upgrading Istio from %s to %s skips a minor version, which is not supported by Istio", currentVersion, targetVersion)
+	}
+
+	objects, err := GetIstioOperatorObjects(options.releaseName)
+	if err != nil {
+		return err
+	}
+	crds := make(object.K8sObjects, 0)
+	objs := make(object.K8sObjects, 0)
+	for _, obj := range objects {
+		if obj.Kind == "CustomResourceDefinition" {
+			crds = append(crds, obj)
+		} else {
+			objs = append(objs, obj)
+		}
+	}
+
+	// the Istio CR must be valid against the CRD of the new version
+	config.schema, err = crdSchema(crds, istioCRDName)
+	if err != nil {
+		return err
+	}
+
+	modified := config.cr.DeepCopy()
+	err = setUpgradedVersion(modified, target)
+	if err != nil {
+		return err
+	}
+
+	changed, err := config.confirm(modified, &options.change)
+	if err != nil {
+		return err
+	}
+
+	if changed && !options.change.dryRun {
+		log.Infof("upgrading Istio from %s to %s", currentVersion, targetVersion)
+
+		ic := &installCommand{cli: c.cli}
+		err = ic.applyResources(crds, objs)
+		if err != nil {
+			return errors.WrapIf(err, "could not upgrade Istio operator")
+		}
+
+		err = config.update(modified)
+		if err != nil {
+			return err
+		}
+
+		err = c.waitForControlPlane(modified)
+		if err != nil {
+			return errors.WrapIf(err, "control plane did not become healthy")
+		}
+
+		log.Infof("Istio control plane upgraded to %s", targetVersion)
+	}
+
+	proxyImage, _, _ := unstructured.NestedString(modified.Object, "spec", "proxy", "image")
+
+	return c.restartOutdatedWorkloads(proxyImage, options)
+}
+
+// setUpgradedVersion sets the version and the component images of the target Istio CR while keeping the rest of the configuration
+func setUpgradedVersion(cr, target *unstructured.Unstructured) error {
+	version, _, _ := unstructured.NestedString(target.Object, "spec", "version")
+	err := unstructured.SetNestedField(cr.Object, version, "spec", "version")
+	if err != nil {
+		return errors.WrapIf(err, "could not set Istio version")
+	}
+
+	for component := range istioComponentImages {
+		image, found, _ := unstructured.NestedString(target.Object, "spec", component, "image")
+		if !found {
+			continue
+		}
+		err = unstructured.SetNestedField(cr.Object, image, "spec", component, "image")
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not set image", "component", component)
+		}
+	}
+
+	return nil
+}
+
+// waitForControlPlane waits for the deployments of the control plane to run the images of the Istio CR
+func (c *upgradeCommand) waitForControlPlane(cr *unstructured.Unstructured) error {
+	var istioCR v1beta1.Istio
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(cr.Object, &istioCR)
+	if err != nil {
+		return errors.WrapIf(err, "could not convert Istio CR")
+	}
+
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	images := make(map[string]string)
+	deployments := make([]k8s.NamespacedNameWithGVK, 0)
+	for _, name := range ControlPlaneDeploymentNames(&istioCR) {
+		images[name], _, _ = unstructured.NestedString(cr.Object, "spec", controlPlaneDeploymentComponents[name], "image")
+		deployments = append(deployments, k8s.NamespacedNameWithGVK{
+			NamespacedName: types.NamespacedName{
+				Name:      name,
+				Namespace: IstioNamespace,
+			},
+			GroupVersionKind: appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		})
+	}
+
+	return k8s.WaitForResourcesConditions(config, deployments, c.cli.WaitOptions(), k8s.ExistsConditionCheck, containerImageConditionCheck(images), k8s.ReadyConditionCheck)
+}
+
+// containerImageConditionCheck checks whether any container of a deployment runs the image expected for its name
+func containerImageConditionCheck(images map[string]string) k8s.ResourceConditionCheck {
+	return func(obj *unstructured.Unstructured, k8serror error) bool {
+		if k8serror != nil {
+			return false
+		}
+
+		image := images[obj.GetName()]
+		if image == "" {
+			return true
+		}
+
+		containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+		for _, container := range containers {
+			if c, ok := container.(map[string]interface{}); ok && c["image"] == image {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func (c *upgradeCommand) restartOutdatedWorkloads(proxyImage string, options *UpgradeOptions) error {
+	cl, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	workloads, err := getSidecarWorkloads(cl, proxyImage)
+	if err != nil {
+		return err
+	}
+
+	if len(workloads) == 0 {
+		log.Info("every sidecar runs the proxy of the control plane")
+		return nil
+	}
+
+	log.Warnf("the sidecars of the following workloads do not run %s", proxyImage)
+	ctx := &output.Context{
+		Out:     c.cli.Out(),
+		Color:   c.cli.Color(),
+		Format:  c.cli.OutputFormat(),
+		Fields:  []string{"Namespace", "Kind", "Name", "Proxy"},
+		Headers: []string{"Namespace", "Kind", "Name", "Proxy"},
+	}
+	err = output.Output(ctx, workloads)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	restartable := restartableWorkloads(workloads)
+	if options.change.dryRun || len(restartable) == 0 {
+		return nil
+	}
+
+	restart := options.restartWorkloads
+	if !restart && c.cli.InteractiveTerminal() {
+		err = survey.AskOne(&survey.Confirm{Message: fmt.Sprintf("Do you want to restart %d workloads?", len(restartable))}, &restart)
+		if err != nil {
+			return errors.WrapIf(err, "could not ask for confirmation")
+		}
+	}
+	if !restart {
+		log.Info("restart the workloads to pick up the new proxy, or use the --restart-workloads flag")
+		return nil
+	}
+
+	return restartWorkloads(c.cli, restartable)
+}
+
+// restartableWorkloads returns the Deployments, StatefulSets and DaemonSets among the given workloads
+func restartableWorkloads(workloads []OutdatedWorkload) []k8s.NamespacedNameWithGVK {
+	restartable := make([]k8s.NamespacedNameWithGVK, 0)
+	for _, workload := range workloads {
+		switch workload.Kind {
+		case "Deployment", "StatefulSet", "DaemonSet":
+			restartable = append(restartable, k8s.NamespacedNameWithGVK{
+				NamespacedName: types.NamespacedName{
+					Name:      workload.Name,
+					Namespace: workload.Namespace,
+				},
+				GroupVersionKind: appsv1.SchemeGroupVersion.WithKind(workload.Kind),
+			})
+		}
+	}
+
+	return restartable
+}
+
+// restartWorkloads triggers a rolling restart of the given workloads and waits for them to become ready
+func restartWorkloads(cli cli.CLI, workloads []k8s.NamespacedNameWithGVK) error {
+	cl, err := cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+	for _, workload := range workloads {
+		log.Infof("restarting %s", workload)
+		err = cl.Patch(context.Background(), workload.Unstructured(), client.ConstantPatch(types.MergePatchType, patch))
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not restart workload", "kind", workload.Kind, "name", workload.Name, "namespace", workload.Namespace)
+		}
+	}
+
+	config, err := cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	return k8s.WaitForResourcesConditions(config, workloads, cli.WaitOptions(), k8s.ExistsConditionCheck, k8s.ReadyConditionCheck)
+}
+
+// getSidecarWorkloads lists the workloads having pods with a sidecar not running the given proxy image,
+// or every workload with a sidecar if the image is empty
+func getSidecarWorkloads(cl client.Client, proxyImage string) ([]OutdatedWorkload, error) {
+	var pods corev1.PodList
+	err := cl.List(context.Background(), &pods)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not list pods")
+	}
+
+	var replicaSets appsv1.ReplicaSetList
+	err = cl.List(context.Background(), &replicaSets)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not list replica sets")
+	}
+	replicaSetOwners := make(map[string]string)
+	for _, rs := range replicaSets.Items {
+		for _, owner := range rs.OwnerReferences {
+			if owner.Kind == "Deployment" {
+				replicaSetOwners[rs.Namespace+"/"+rs.Name] = owner.Name
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	workloads := make([]OutdatedWorkload, 0)
+	for _, pod := range pods.Items {
+		image := ""
+		for _, container := range pod.Spec.Containers {
+			if container.Name == proxyContainerName {
+				image = container.Image
+			}
+		}
+		if image == "" || image == proxyImage {
+			continue
+		}
+
+		workload := OutdatedWorkload{
+			Namespace: pod.Namespace,
+			Kind:      "Pod",
+			Name:      pod.Name,
+			Proxy:     image,
+		}
+		for _, owner := range pod.OwnerReferences {
+			workload.Kind = owner.Kind
+			workload.Name = owner.Name
+			if deployment, ok := replicaSetOwners[pod.Namespace+"/"+owner.Name]; owner.Kind == "ReplicaSet" && ok {
+				workload.Kind = "Deployment"
+				workload.Name = deployment
+			}
+		}
+
+		key := strings.Join([]string{workload.Namespace, workload.Kind, workload.Name, workload.Proxy}, "/")
+		if !seen[key] {
+			seen[key] = true
+			workloads = append(workloads, workload)
+		}
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].Namespace != workloads[j].Namespace {
+			return workloads[i].Namespace < workloads[j].Namespace
+		}
+		return workloads[i].Name < workloads[j].Name
+	})
+
+	return workloads, nil
+}
+
+// crdSchema returns the validation schema of the named CRD among the given objects
+func crdSchema(crds object.K8sObjects, name string) (*apiextensionsv1beta1.JSONSchemaProps, error) {
+	for _, obj := range crds {
+		if obj.Name != name {
+			continue
+		}
+
+		var crd apiextensionsv1beta1.CustomResourceDefinition
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredObject().Object, &crd)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not convert CRD", "name", name)
+		}
+		if crd.Spec.Validation == nil {
+			return nil, nil
+		}
+
+		return crd.Spec.Validation.OpenAPIV3Schema, nil
+	}
+
+	return nil, errors.NewWithDetails("CRD not found", "name", name)
+}
+
+// minorVersion returns the major.minor part of a version
+func minorVersion(version string) string {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+
+	return parts[0] + "." + parts[1]
+}
+
+// compareMinorVersions returns the difference of the minor versions if the major versions match
+func compareMinorVersions(a, b string) int {
+	parse := func(version string) (int, int) {
+		parts := strings.SplitN(minorVersion(version), ".", 2)
+		if len(parts) != 2 {
+			return 0, 0
+		}
+		major, _ := strconv.Atoi(parts[0])
+		minor, _ := strconv.Atoi(parts[1])
+		return major, minor
+	}
+
+	aMajor, aMinor := parse(a)
+	bMajor, bMinor := parse(b)
+	if aMajor != bMajor {
+		return (aMajor - bMajor) * 100
+	}
+
+	return aMinor - bMinor
+}
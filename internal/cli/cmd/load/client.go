@@ -0,0 +1,400 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/HdrHistogram/hdrhistogram-go"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
+	"github.com/banzaicloud/backyards-cli/internal/endpoint"
+	"github.com/banzaicloud/backyards-cli/pkg/graphql"
+)
+
+const (
+	targetProxy       = "proxy"
+	targetPortforward = "port-forward"
+	targetIngress     = "ingress"
+
+	ingressGatewayServiceName = "istio-ingressgateway"
+
+	// latencies are recorded in microseconds between 1µs and a minute with 3 significant digits
+	maxRecordedLatency = int64(time.Minute / time.Microsecond)
+	latencySigFigs     = 3
+
+	// transportErrorCode is the status code recorded for requests which got no response
+	transportErrorCode = "error"
+
+	liveReportInterval = 5 * time.Second
+)
+
+var clientTargets = []string{targetProxy, targetPortforward, targetIngress}
+
+// clientLoad sends the requests of a load from the CLI itself
+type clientLoad struct {
+	client      *http.Client
+	url         string
+	method      string
+	body        string
+	headers     map[string]string
+	concurrency int
+}
+
+type requestResult struct {
+	code    string
+	failed  bool
+	latency time.Duration
+}
+
+// latencyStats collects the latencies and status codes of the responses
+type latencyStats struct {
+	histogram *hdrhistogram.Histogram
+	codes     graphql.GenerateLoadResponse
+	errors    int
+	start     time.Time
+}
+
+func newLatencyStats() *latencyStats {
+	return &latencyStats{
+		histogram: hdrhistogram.New(1, maxRecordedLatency, latencySigFigs),
+		codes:     make(graphql.GenerateLoadResponse),
+		start:     time.Now(),
+	}
+}
+
+func (s *latencyStats) record(result requestResult) {
+	// latencies above the highest trackable value are recorded as the highest one
+	latency := int64(result.latency / time.Microsecond)
+	if latency > maxRecordedLatency {
+		latency = maxRecordedLatency
+	}
+	_ = s.histogram.RecordValue(latency)
+
+	s.codes[result.code]++
+	if result.failed {
+		s.errors++
+	}
+}
+
+func (s *latencyStats) reset() {
+	s.histogram.Reset()
+	s.codes = make(graphql.GenerateLoadResponse)
+	s.errors = 0
+	s.start = time.Now()
+}
+
+func (s *latencyStats) quantile(q float64) time.Duration {
+	return time.Duration(s.histogram.ValueAtQuantile(q)) * time.Microsecond
+}
+
+func (s *latencyStats) throughput() float64 {
+	return float64(s.histogram.TotalCount()) / time.Since(s.start).Seconds()
+}
+
+// runClientSide sends the load phases from the CLI and summarizes the latencies of each phase
+func (c *loadCommand) runClientSide(options *LoadOptions, req graphql.GenerateLoadRequest, phases []Phase) error {
+	if phases == nil {
+		phases = []Phase{constantPhase("constant", req.Frequency, req.Duration)}
+	}
+
+	ep, err := c.getClientEndpoint(options, req)
+	if err != nil {
+		return err
+	}
+	defer ep.Close()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = options.concurrency
+
+	load := &clientLoad{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   options.requestTimeout,
+		},
+		url:         ep.URLForPath(req.Endpoint),
+		method:      req.Method,
+		body:        req.Body,
+		headers:     req.Headers,
+		concurrency: options.concurrency,
+	}
+
+	// the first request makes sure that the target can be reached before the load is started
+	result := load.send(time.Now())
+	if result.code == transportErrorCode {
+		return errors.NewWithDetails("could not reach the target of the load", "target", options.target, "url", load.url)
+	}
+
+	results := make([]LatencyResult, 0, len(phases))
+	for _, phase := range phases {
+		log.WithFields(log.Fields{
+			"service":     options.serviceID,
+			"target":      options.target,
+			"phase":       phase.Name,
+			"rps":         phase.Rate(),
+			"duration":    phase.Duration(),
+			"concurrency": options.concurrency,
+		}).Info("sending load")
+
+		results = append(results, load.run(phase))
+	}
+
+	return OutputLatencyResults(c.cli, results)
+}
+
+// getClientEndpoint returns the local endpoint through which the requests reach the service or the ingress gateway
+func (c *loadCommand) getClientEndpoint(options *LoadOptions, req graphql.GenerateLoadRequest) (endpoint.Endpoint, error) {
+	switch options.target {
+	case targetProxy:
+		config, err := c.cli.GetK8sConfig()
+		if err != nil {
+			return nil, err
+		}
+		return endpoint.NewProxyEndpoint(0, config, endpoint.K8sService{
+			Name:      req.Service,
+			Namespace: req.Namespace,
+			Port:      req.Port,
+		})
+	case targetPortforward:
+		cl, err := c.cli.GetK8sClient()
+		if err != nil {
+			return nil, err
+		}
+		var service corev1.Service
+		err = cl.Get(context.Background(), types.NamespacedName{
+			Name:      req.Service,
+			Namespace: req.Namespace,
+		}, &service)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not get service", "service", req.Namespace+"/"+req.Service)
+		}
+		port, err := podPort(cl, &service, req.Port)
+		if err != nil {
+			return nil, err
+		}
+		pf, err := c.cli.GetPortforwardForPod(service.Spec.Selector, service.Namespace, 0, port)
+		if err != nil {
+			return nil, err
+		}
+		err = pf.Run()
+		if err != nil {
+			return nil, err
+		}
+		return endpoint.NewPortforwardEndpoint(pf, nil), nil
+	case targetIngress:
+		url := strings.TrimSuffix(options.ingressURL, "/")
+		if url == "" {
+			cl, err := c.cli.GetK8sClient()
+			if err != nil {
+				return nil, err
+			}
+			url, err = ingressGatewayURL(cl)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return endpoint.NewExternalEndpoint(url, nil), nil
+	default:
+		return nil, errors.NewWithDetails("unknown load target", "target", options.target, "supported", clientTargets)
+	}
+}
+
+// podPort returns the container port the service port is forwarded to
+func podPort(cl client.Client, service *corev1.Service, port int) (int, error) {
+	for _, p := range service.Spec.Ports {
+		if int(p.Port) != port {
+			continue
+		}
+
+		if p.TargetPort.Type == intstr.Int {
+			if p.TargetPort.IntVal == 0 {
+				return port, nil
+			}
+			return int(p.TargetPort.IntVal), nil
+		}
+
+		var pods corev1.PodList
+		err := cl.List(context.Background(), &pods, client.InNamespace(service.Namespace), client.MatchingLabels(service.Spec.Selector))
+		if err != nil {
+			return 0, errors.WrapIfWithDetails(err, "could not list pods", "namespace", service.Namespace)
+		}
+		for _, pod := range pods.Items {
+			for _, container := range pod.Spec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == p.TargetPort.StrVal {
+						return int(containerPort.ContainerPort), nil
+					}
+				}
+			}
+		}
+
+		return 0, errors.NewWithDetails("could not find the target port of the service in its pods", "service", service.Namespace+"/"+service.Name, "targetPort", p.TargetPort.StrVal)
+	}
+
+	return 0, errors.NewWithDetails("service does not expose port", "service", service.Namespace+"/"+service.Name, "port", port)
+}
+
+// ingressGatewayURL returns the URL of the external address of the Istio ingress gateway
+func ingressGatewayURL(cl client.Client) (string, error) {
+	var service corev1.Service
+	err := cl.Get(context.Background(), types.NamespacedName{
+		Name:      ingressGatewayServiceName,
+		Namespace: istio.IstioNamespace,
+	}, &service)
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "could not get ingress gateway service", "namespace", istio.IstioNamespace)
+	}
+
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		host := ingress.IP
+		if host == "" {
+			host = ingress.Hostname
+		}
+		if host != "" {
+			return "http://" + host, nil
+		}
+	}
+
+	return "", errors.NewWithDetails("ingress gateway has no external address, set it with --ingress-url", "service", istio.IstioNamespace+"/"+ingressGatewayServiceName)
+}
+
+// run sends the steps of the phase and reports the latencies of the responses periodically and at the end
+func (l *clientLoad) run(phase Phase) LatencyResult {
+	// the latency is measured from the time a request should have been sent, so that the requests
+	// delayed by the saturated workers are not omitted from the results
+	schedule := make(chan time.Time)
+	results := make(chan requestResult, l.concurrency)
+
+	var wg sync.WaitGroup
+	for i := 0; i < l.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for intended := range schedule {
+				results <- l.send(intended)
+			}
+		}()
+	}
+
+	go func() {
+		for _, step := range phase.Steps {
+			interval := time.Second / time.Duration(step.Frequency)
+			start := time.Now()
+			for i := 0; i < step.Frequency*step.Duration; i++ {
+				intended := start.Add(time.Duration(i) * interval)
+				time.Sleep(time.Until(intended))
+				schedule <- intended
+			}
+		}
+		close(schedule)
+		wg.Wait()
+		close(results)
+	}()
+
+	total := newLatencyStats()
+	window := newLatencyStats()
+	ticker := time.NewTicker(liveReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				return newLatencyResult(phase, total)
+			}
+			total.record(result)
+			window.record(result)
+		case <-ticker.C:
+			log.WithFields(log.Fields{
+				"phase":  phase.Name,
+				"rps":    fmt.Sprintf("%.1f", window.throughput()),
+				"p50":    window.quantile(50),
+				"p90":    window.quantile(90),
+				"p99":    window.quantile(99),
+				"errors": window.errors,
+			}).Info("load progress")
+			window.reset()
+		}
+	}
+}
+
+func (l *clientLoad) send(intended time.Time) requestResult {
+	var body io.Reader
+	if l.body != "" {
+		body = strings.NewReader(l.body)
+	}
+
+	req, err := http.NewRequest(l.method, l.url, body)
+	if err != nil {
+		return requestResult{code: transportErrorCode, failed: true, latency: time.Since(intended)}
+	}
+	for key, value := range l.headers {
+		if strings.EqualFold(key, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		log.Debugf("request failed: %s", err)
+		return requestResult{code: transportErrorCode, failed: true, latency: time.Since(intended)}
+	}
+	// the body is read so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	return requestResult{
+		code:    strconv.Itoa(resp.StatusCode),
+		failed:  resp.StatusCode >= http.StatusBadRequest,
+		latency: time.Since(intended),
+	}
+}
+
+func newLatencyResult(phase Phase, stats *latencyStats) LatencyResult {
+	milliseconds := func(d time.Duration) float64 {
+		return math.Round(float64(d)/float64(time.Millisecond)*100) / 100
+	}
+
+	return LatencyResult{
+		Phase:      phase.Name,
+		Rate:       phase.Rate(),
+		Duration:   phase.Duration(),
+		Requests:   int(stats.histogram.TotalCount()),
+		Errors:     stats.errors,
+		Throughput: math.Round(stats.throughput()*10) / 10,
+		P50:        milliseconds(stats.quantile(50)),
+		P90:        milliseconds(stats.quantile(90)),
+		P99:        milliseconds(stats.quantile(99)),
+		Max:        milliseconds(time.Duration(stats.histogram.Max()) * time.Microsecond),
+		Codes:      Summarize(stats.codes),
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/routing/common"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/graphql"
+)
+
+type loadCommand struct {
+	cli cli.CLI
+}
+
+type LoadOptions struct {
+	serviceID string
+	port      int
+	path      string
+	method    string
+	headers   []string
+	body      string
+	frequency int
+	duration  int
+	profile   string
+	scenario  string
+
+	clientSide     bool
+	target         string
+	ingressURL     string
+	concurrency    int
+	requestTimeout time.Duration
+}
+
+func NewLoadOptions() *LoadOptions {
+	return &LoadOptions{
+		path:      "/",
+		method:    http.MethodGet,
+		frequency: 10,
+		duration:  30,
+
+		target:         targetProxy,
+		concurrency:    50,
+		requestTimeout: 10 * time.Second,
+	}
+}
+
+func NewLoadCommand(cli cli.CLI, options *LoadOptions) *cobra.Command {
+	c := &loadCommand{
+		cli: cli,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "load [[--service=]namespace/servicename] [flags]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Send load to a service in the mesh",
+		Long: `Send load to a service in the mesh.
+
+The requests are sent by Backyards from inside the mesh, so they go through the
+same sidecars and routing rules as the regular traffic of the service. The number
+of responses per status code is printed when the load is finished.
+
+The request body can be read from a file by prefixing its path with '@'.
+
+Instead of a constant rate, the load can follow a profile:
+
+  ramp:<from>-<to>rps/<duration>               rate changing linearly in 10 steps
+  spike:<base>rps,<peak>rps@<start>/<duration> peak rate between two periods of base rate
+  step:<rate>,<rate>,...rps/<duration>         each rate for the given duration
+
+or the phases of a YAML scenario file, where each phase has either a rate and
+a duration or a profile:
+
+  phases:
+  - name: warmup
+    rps: 10
+    duration: 30s
+  - name: burst
+    profile: spike:50rps,500rps@1m/30s
+
+The responses are summarized per phase in this case.
+
+With --client-side the requests are sent by the CLI instead, which records the
+latency of every response and reports the p50, p90 and p99 latencies, the
+throughput and the errors periodically and at the end of each phase. Requests
+with no response or with a 4xx or 5xx status code are counted as errors. The
+requests reach the service
+
+  proxy         through the Kubernetes API server proxy
+  port-forward  through a port-forward to one of the pods of the service
+  ingress       through the Istio ingress gateway, whose address can be set with
+                --ingress-url; set the Host header to match its routing rules
+
+Use the json output to compare the results of several runs.`,
+		Example: `  # Send 10 GET requests per second to the default port of a service for 30 seconds.
+  backyards load backyards-demo/catalog
+
+  # Post a JSON body with custom headers.
+  backyards load backyards-demo/bookings --port 8080 --path /bookings --method POST \
+    --header Content-Type=application/json --body @booking.json --rps 50 --duration 60
+
+  # Ramp the load up from 10 to 200 requests per second in 5 minutes.
+  backyards load backyards-demo/catalog --profile ramp:10-200rps/5m
+
+  # Measure the latencies of the requests sent through the ingress gateway.
+  backyards load backyards-demo/movies --client-side --target ingress --header Host=movies.example.com -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if len(args) > 0 {
+				options.serviceID = args[0]
+			}
+
+			if options.serviceID == "" {
+				return errors.New("service must be specified")
+			}
+
+			if options.profile != "" && options.scenario != "" {
+				return errors.New("the --profile and --scenario flags are mutually exclusive")
+			}
+
+			if !options.clientSide {
+				for _, flag := range []string{"target", "ingress-url", "concurrency", "request-timeout"} {
+					if cmd.Flags().Changed(flag) {
+						return errors.Errorf("the --%s flag requires --client-side", flag)
+					}
+				}
+			}
+
+			return c.run(options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.serviceID, "service", options.serviceID, "Service to send the load to (namespace/name)")
+	flags.IntVar(&options.port, "port", options.port, "Port of the service (defaults to the first port of the service)")
+	flags.StringVar(&options.path, "path", options.path, "Path of the requests")
+	flags.StringVar(&options.method, "method", options.method, "HTTP method of the requests")
+	flags.StringArrayVar(&options.headers, "header", options.headers, "Header of the requests in key=value format, can be repeated")
+	flags.StringVar(&options.body, "body", options.body, "Body of the requests, or @file to read it from a file")
+	flags.IntVar(&options.frequency, "rps", options.frequency, "Number of requests per second")
+	flags.IntVar(&options.duration, "duration", options.duration, "Duration in seconds")
+	flags.StringVar(&options.profile, "profile", options.profile, "Load profile, e.g. ramp:10-200rps/5m, spike:50rps,500rps@2m/30s or step:10,50,100rps/1m")
+	flags.StringVar(&options.scenario, "scenario", options.scenario, "YAML file of load phases")
+	flags.BoolVar(&options.clientSide, "client-side", options.clientSide, "Send the requests from the CLI and measure their latency")
+	flags.StringVar(&options.target, "target", options.target, fmt.Sprintf("Where the client side requests are sent through (%s)", strings.Join(clientTargets, ", ")))
+	flags.StringVar(&options.ingressURL, "ingress-url", options.ingressURL, "URL of the ingress gateway (defaults to the external address of its service)")
+	flags.IntVar(&options.concurrency, "concurrency", options.concurrency, "Maximum number of client side requests in flight")
+	flags.DurationVar(&options.requestTimeout, "request-timeout", options.requestTimeout, "Timeout of the client side requests")
+
+	return cmd
+}
+
+func (c *loadCommand) run(options *LoadOptions) error {
+	phases, err := getPhases(options)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.getRequest(options)
+	if err != nil {
+		return err
+	}
+
+	if options.clientSide {
+		return c.runClientSide(options, req, phases)
+	}
+
+	if phases == nil {
+		log.WithFields(log.Fields{
+			"service":  options.serviceID,
+			"port":     req.Port,
+			"rps":      req.Frequency,
+			"duration": req.Duration,
+		}).Info("sending load")
+
+		response, err := GenerateLoad(c.cli, req)
+		if err != nil {
+			return err
+		}
+
+		return OutputResponse(c.cli, response)
+	}
+
+	results := make([]PhaseResult, 0, len(phases))
+	for _, phase := range phases {
+		log.WithFields(log.Fields{
+			"service":  options.serviceID,
+			"port":     req.Port,
+			"phase":    phase.Name,
+			"rps":      phase.Rate(),
+			"duration": phase.Duration(),
+		}).Info("sending load")
+
+		response := make(graphql.GenerateLoadResponse)
+		for _, step := range phase.Steps {
+			req.Frequency = step.Frequency
+			req.Duration = step.Duration
+			stepResponse, err := GenerateLoad(c.cli, req)
+			if err != nil {
+				return errors.WrapIfWithDetails(err, "load phase failed", "phase", phase.Name)
+			}
+			for code, count := range stepResponse {
+				response[code] += count
+			}
+		}
+
+		results = append(results, newPhaseResult(phase, response))
+	}
+
+	return OutputPhaseResults(c.cli, results)
+}
+
+// getPhases returns the phases of the load profile or scenario, or nil for constant rate load
+func getPhases(options *LoadOptions) ([]Phase, error) {
+	var phases []Phase
+	var err error
+
+	switch {
+	case options.profile != "":
+		phases, err = ParseProfile(options.profile)
+	case options.scenario != "":
+		phases, err = ReadScenario(options.scenario)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, phase := range phases {
+		for _, step := range phase.Steps {
+			if step.Frequency < 1 {
+				return nil, errors.NewWithDetails("the rate of the load must be positive", "phase", phase.Name)
+			}
+		}
+	}
+
+	return phases, nil
+}
+
+// getRequest validates the options against the service and builds the load request
+func (c *loadCommand) getRequest(options *LoadOptions) (graphql.GenerateLoadRequest, error) {
+	serviceName, err := common.ParseServiceID(options.serviceID)
+	if err != nil {
+		return graphql.GenerateLoadRequest{}, err
+	}
+
+	if options.frequency < 1 || options.duration < 1 {
+		return graphql.GenerateLoadRequest{}, errors.New("the rate and the duration of the load must be positive")
+	}
+
+	if options.concurrency < 1 {
+		return graphql.GenerateLoadRequest{}, errors.New("the concurrency of the load must be positive")
+	}
+
+	headers, err := parseHeaders(options.headers)
+	if err != nil {
+		return graphql.GenerateLoadRequest{}, err
+	}
+
+	body, err := readBody(options.body)
+	if err != nil {
+		return graphql.GenerateLoadRequest{}, err
+	}
+
+	service, err := common.GetServiceByName(c.cli, serviceName)
+	if err != nil {
+		if k8serrors.IsNotFound(errors.Cause(err)) {
+			return graphql.GenerateLoadRequest{}, err
+		}
+		return graphql.GenerateLoadRequest{}, errors.WrapIf(err, "could not get service")
+	}
+
+	port, err := servicePort(service, options.port)
+	if err != nil {
+		return graphql.GenerateLoadRequest{}, err
+	}
+
+	path := options.path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return graphql.GenerateLoadRequest{
+		Namespace: service.Namespace,
+		Service:   service.Name,
+		Port:      port,
+		Endpoint:  path,
+		Method:    strings.ToUpper(options.method),
+		Body:      body,
+		Frequency: options.frequency,
+		Duration:  options.duration,
+		Headers:   headers,
+	}, nil
+}
+
+// GenerateLoad sends the load through the Backyards API and waits for it to finish
+func GenerateLoad(cli cli.CLI, req graphql.GenerateLoadRequest) (graphql.GenerateLoadResponse, error) {
+	client, err := common.GetGraphQLClient(cli)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not get initialized graphql client")
+	}
+	defer client.Close()
+
+	response, err := client.GenerateLoad(req)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not generate load")
+	}
+
+	return response, nil
+}
+
+func servicePort(service *corev1.Service, port int) (int, error) {
+	if len(service.Spec.Ports) == 0 {
+		return 0, errors.NewWithDetails("service has no ports", "service", service.Namespace+"/"+service.Name)
+	}
+
+	if port == 0 {
+		return int(service.Spec.Ports[0].Port), nil
+	}
+
+	for _, p := range service.Spec.Ports {
+		if int(p.Port) == port {
+			return port, nil
+		}
+	}
+
+	return 0, errors.NewWithDetails("service does not expose port", "service", service.Namespace+"/"+service.Name, "port", port)
+}
+
+func parseHeaders(headers []string) (map[string]string, error) {
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	parsed := make(map[string]string, len(headers))
+	for _, header := range headers {
+		parts := strings.SplitN(header, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("invalid header '%s': format must be <key>=<value>", header)
+		}
+		parsed[parts[0]] = parts[1]
+	}
+
+	return parsed, nil
+}
+
+func readBody(body string) (string, error) {
+	if !strings.HasPrefix(body, "@") {
+		return body, nil
+	}
+
+	content, err := ioutil.ReadFile(body[1:])
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "could not read body", "file", body[1:])
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,164 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/graphql"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+)
+
+// StatusCodeCount is the number of responses with a status code
+type StatusCodeCount struct {
+	Code       string `json:"code"`
+	Count      int    `json:"count"`
+	Percentage string `json:"percentage"`
+}
+
+// PhaseResult is the summary of the responses of a load phase
+type PhaseResult struct {
+	Phase    string            `json:"phase"`
+	Rate     string            `json:"rate"`
+	Duration int               `json:"duration"`
+	Requests int               `json:"requests"`
+	Codes    []StatusCodeCount `json:"codes"`
+}
+
+// CodeList is used by the table output
+func (r PhaseResult) CodeList() string {
+	codes := make([]string, len(r.Codes))
+	for i, code := range r.Codes {
+		codes[i] = fmt.Sprintf("%s: %d", code.Code, code.Count)
+	}
+
+	return strings.Join(codes, ", ")
+}
+
+// LatencyResult is the summary of the responses of a load phase sent from the CLI, latencies are in milliseconds
+type LatencyResult struct {
+	Phase      string            `json:"phase"`
+	Rate       string            `json:"rate"`
+	Duration   int               `json:"duration"`
+	Requests   int               `json:"requests"`
+	Errors     int               `json:"errors"`
+	Throughput float64           `json:"throughput"`
+	P50        float64           `json:"p50"`
+	P90        float64           `json:"p90"`
+	P99        float64           `json:"p99"`
+	Max        float64           `json:"max"`
+	Codes      []StatusCodeCount `json:"codes"`
+}
+
+// CodeList is used by the table output
+func (r LatencyResult) CodeList() string {
+	return PhaseResult{Codes: r.Codes}.CodeList()
+}
+
+func newPhaseResult(phase Phase, response graphql.GenerateLoadResponse) PhaseResult {
+	requests := 0
+	for _, count := range response {
+		requests += count
+	}
+
+	return PhaseResult{
+		Phase:    phase.Name,
+		Rate:     phase.Rate(),
+		Duration: phase.Duration(),
+		Requests: requests,
+		Codes:    Summarize(response),
+	}
+}
+
+// Summarize returns the response counts ordered by status code
+func Summarize(response graphql.GenerateLoadResponse) []StatusCodeCount {
+	total := 0
+	for _, count := range response {
+		total += count
+	}
+
+	counts := make([]StatusCodeCount, 0, len(response))
+	for code, count := range response {
+		counts = append(counts, StatusCodeCount{
+			Code:       code,
+			Count:      count,
+			Percentage: fmt.Sprintf("%.1f%%", 100*float64(count)/float64(total)),
+		})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].Code < counts[j].Code
+	})
+
+	return counts
+}
+
+// OutputResponse prints the number of responses per status code
+func OutputResponse(cli cli.CLI, response graphql.GenerateLoadResponse) error {
+	ctx := &output.Context{
+		Out:     cli.Out(),
+		Color:   cli.Color(),
+		Format:  cli.OutputFormat(),
+		Fields:  []string{"Code", "Count", "Percentage"},
+		Headers: []string{"Status code", "Requests", "Share"},
+	}
+
+	err := output.Output(ctx, Summarize(response))
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
+
+// OutputPhaseResults prints the number of responses per status code of each phase
+func OutputPhaseResults(cli cli.CLI, results []PhaseResult) error {
+	ctx := &output.Context{
+		Out:     cli.Out(),
+		Color:   cli.Color(),
+		Format:  cli.OutputFormat(),
+		Fields:  []string{"Phase", "Rate", "Duration", "Requests", "CodeList"},
+		Headers: []string{"Phase", "Rate", "Duration (s)", "Requests", "Status codes"},
+	}
+
+	err := output.Output(ctx, results)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
+
+// OutputLatencyResults prints the latencies, throughput and errors of each phase
+func OutputLatencyResults(cli cli.CLI, results []LatencyResult) error {
+	ctx := &output.Context{
+		Out:     cli.Out(),
+		Color:   cli.Color(),
+		Format:  cli.OutputFormat(),
+		Fields:  []string{"Phase", "Rate", "Requests", "Throughput", "Errors", "P50", "P90", "P99", "Max", "CodeList"},
+		Headers: []string{"Phase", "Rate", "Requests", "Throughput (rps)", "Errors", "p50 (ms)", "p90 (ms)", "p99 (ms)", "Max (ms)", "Status codes"},
+	}
+
+	err := output.Output(ctx, results)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
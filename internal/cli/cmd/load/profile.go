@@ -0,0 +1,269 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	profileRamp  = "ramp"
+	profileSpike = "spike"
+	profileStep  = "step"
+
+	// rampSteps is the number of constant rate steps a ramp is split into
+	rampSteps = 10
+)
+
+var (
+	// ramp:10-200rps/5m
+	rampProfileRegexp = regexp.MustCompile(`^(\d+)-(\d+)rps/(\S+)$`)
+	// spike:50rps,500rps@2m/30s
+	spikeProfileRegexp = regexp.MustCompile(`^(\d+)rps,(\d+)rps@([^/]+)/(\S+)$`)
+	// step:10,50,100rps/1m
+	stepProfileRegexp = regexp.MustCompile(`^(\d+(?:,\d+)*)rps/(\S+)$`)
+)
+
+// Phase is a part of the load whose results are aggregated, sent as a series of constant rate steps
+type Phase struct {
+	Name  string
+	Steps []Step
+}
+
+// Step is a constant rate load sent by a single load request
+type Step struct {
+	Frequency int
+	Duration  int
+}
+
+// Scenario is a series of load phases read from a YAML file
+type Scenario struct {
+	Phases []ScenarioPhase `json:"phases"`
+}
+
+// ScenarioPhase is either a constant rate load or a load profile
+type ScenarioPhase struct {
+	Name     string `json:"name"`
+	RPS      int    `json:"rps,omitempty"`
+	Duration string `json:"duration,omitempty"`
+	Profile  string `json:"profile,omitempty"`
+}
+
+// Rate returns the rate of the phase, or its range if it changes over time
+func (p Phase) Rate() string {
+	min, max := p.Steps[0].Frequency, p.Steps[0].Frequency
+	for _, step := range p.Steps {
+		if step.Frequency < min {
+			min = step.Frequency
+		}
+		if step.Frequency > max {
+			max = step.Frequency
+		}
+	}
+
+	if min == max {
+		return fmt.Sprintf("%drps", min)
+	}
+
+	return fmt.Sprintf("%d-%drps", min, max)
+}
+
+// Duration returns the total duration of the phase in seconds
+func (p Phase) Duration() int {
+	duration := 0
+	for _, step := range p.Steps {
+		duration += step.Duration
+	}
+
+	return duration
+}
+
+func constantPhase(name string, frequency, duration int) Phase {
+	return Phase{
+		Name: name,
+		Steps: []Step{
+			{Frequency: frequency, Duration: duration},
+		},
+	}
+}
+
+// ParseProfile parses a load profile like ramp:10-200rps/5m, spike:50rps,500rps@2m/30s or step:10,50,100rps/1m
+func ParseProfile(profile string) ([]Phase, error) {
+	parts := strings.SplitN(profile, ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid load profile '%s': format must be <type>:<parameters>", profile)
+	}
+
+	switch parts[0] {
+	case profileRamp:
+		m := rampProfileRegexp.FindStringSubmatch(parts[1])
+		if m == nil {
+			return nil, errors.Errorf("invalid ramp profile '%s': format must be ramp:<from>-<to>rps/<duration>", profile)
+		}
+		from, to := atoi(m[1]), atoi(m[2])
+		duration, err := parseSeconds(m[3])
+		if err != nil {
+			return nil, err
+		}
+		return []Phase{rampPhase(profileRamp, from, to, duration)}, nil
+	case profileSpike:
+		m := spikeProfileRegexp.FindStringSubmatch(parts[1])
+		if m == nil {
+			return nil, errors.Errorf("invalid spike profile '%s': format must be spike:<base>rps,<peak>rps@<start>/<duration>", profile)
+		}
+		base, peak := atoi(m[1]), atoi(m[2])
+		start, err := parseSeconds(m[3])
+		if err != nil {
+			return nil, err
+		}
+		duration, err := parseSeconds(m[4])
+		if err != nil {
+			return nil, err
+		}
+		// the base load is sent for the same time after the spike as before it
+		return []Phase{
+			constantPhase("base", base, start),
+			constantPhase(profileSpike, peak, duration),
+			constantPhase("recovery", base, start),
+		}, nil
+	case profileStep:
+		m := stepProfileRegexp.FindStringSubmatch(parts[1])
+		if m == nil {
+			return nil, errors.Errorf("invalid step profile '%s': format must be step:<rate>,<rate>,...rps/<step duration>", profile)
+		}
+		duration, err := parseSeconds(m[2])
+		if err != nil {
+			return nil, err
+		}
+		rates := strings.Split(m[1], ",")
+		phases := make([]Phase, len(rates))
+		for i, rate := range rates {
+			phases[i] = constantPhase(fmt.Sprintf("step-%d", i+1), atoi(rate), duration)
+		}
+		return phases, nil
+	default:
+		return nil, errors.NewWithDetails("unknown load profile type", "type", parts[0], "supported", []string{profileRamp, profileSpike, profileStep})
+	}
+}
+
+// ReadScenario reads the phases of a load scenario file
+func ReadScenario(file string) ([]Phase, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not read scenario", "file", file)
+	}
+
+	var scenario Scenario
+	err = yaml.UnmarshalStrict(content, &scenario)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not parse scenario", "file", file)
+	}
+
+	if len(scenario.Phases) == 0 {
+		return nil, errors.NewWithDetails("scenario has no phases", "file", file)
+	}
+
+	phases := make([]Phase, 0)
+	for i, p := range scenario.Phases {
+		name := p.Name
+		if name == "" {
+			name = fmt.Sprintf("phase-%d", i+1)
+		}
+
+		if p.Profile != "" {
+			profilePhases, err := ParseProfile(p.Profile)
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "invalid scenario phase", "phase", name)
+			}
+			for _, profilePhase := range profilePhases {
+				if len(profilePhases) > 1 {
+					profilePhase.Name = name + "/" + profilePhase.Name
+				} else {
+					profilePhase.Name = name
+				}
+				phases = append(phases, profilePhase)
+			}
+			continue
+		}
+
+		duration, err := parseSeconds(p.Duration)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "invalid scenario phase", "phase", name)
+		}
+		if p.RPS < 1 {
+			return nil, errors.NewWithDetails("scenario phase requires either a positive rate or a profile", "phase", name)
+		}
+		phases = append(phases, constantPhase(name, p.RPS, duration))
+	}
+
+	return phases, nil
+}
+
+// rampPhase splits a linearly changing rate into constant rate steps
+func rampPhase(name string, from, to, duration int) Phase {
+	steps := rampSteps
+	if duration < steps {
+		steps = duration
+	}
+
+	phase := Phase{
+		Name:  name,
+		Steps: make([]Step, steps),
+	}
+	for i := 0; i < steps; i++ {
+		frequency := from
+		if steps > 1 {
+			frequency = from + (to-from)*i/(steps-1)
+		}
+		// the remainder of the duration is spread over the first steps
+		stepDuration := duration / steps
+		if i < duration%steps {
+			stepDuration++
+		}
+		phase.Steps[i] = Step{
+			Frequency: frequency,
+			Duration:  stepDuration,
+		}
+	}
+
+	return phase
+}
+
+// parseSeconds parses a duration of whole seconds, as the load requests are sent with second precision
+func parseSeconds(value string) (int, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "invalid duration", "duration", value)
+	}
+	if duration < time.Second || duration%time.Second != 0 {
+		return 0, errors.NewWithDetails("duration must be a positive number of whole seconds", "duration", value)
+	}
+
+	return int(duration / time.Second), nil
+}
+
+func atoi(value string) int {
+	// the regular expressions only match digits
+	i, _ := strconv.Atoi(value)
+	return i
+}
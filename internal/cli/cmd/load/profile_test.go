@@ -0,0 +1,204 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseProfile(t *testing.T) {
+	tests := map[string]struct {
+		profile  string
+		expected []Phase
+		err      bool
+	}{
+		"ramp": {
+			profile: "ramp:10-100rps/1m",
+			expected: []Phase{
+				{Name: "ramp", Steps: []Step{
+					{10, 6}, {20, 6}, {30, 6}, {40, 6}, {50, 6}, {60, 6}, {70, 6}, {80, 6}, {90, 6}, {100, 6},
+				}},
+			},
+		},
+		"short ramp": {
+			profile: "ramp:100-10rps/3s",
+			expected: []Phase{
+				{Name: "ramp", Steps: []Step{{100, 1}, {55, 1}, {10, 1}}},
+			},
+		},
+		"ramp with remainder": {
+			profile: "ramp:10-10rps/25s",
+			expected: []Phase{
+				{Name: "ramp", Steps: []Step{
+					{10, 3}, {10, 3}, {10, 3}, {10, 3}, {10, 3}, {10, 2}, {10, 2}, {10, 2}, {10, 2}, {10, 2},
+				}},
+			},
+		},
+		"spike": {
+			profile: "spike:50rps,500rps@2m/30s",
+			expected: []Phase{
+				{Name: "base", Steps: []Step{{50, 120}}},
+				{Name: "spike", Steps: []Step{{500, 30}}},
+				{Name: "recovery", Steps: []Step{{50, 120}}},
+			},
+		},
+		"step": {
+			profile: "step:10,50,100rps/1m",
+			expected: []Phase{
+				{Name: "step-1", Steps: []Step{{10, 60}}},
+				{Name: "step-2", Steps: []Step{{50, 60}}},
+				{Name: "step-3", Steps: []Step{{100, 60}}},
+			},
+		},
+		"missing type": {
+			profile: "10-100rps/1m",
+			err:     true,
+		},
+		"unknown type": {
+			profile: "sine:10-100rps/1m",
+			err:     true,
+		},
+		"invalid parameters": {
+			profile: "ramp:10rps/1m",
+			err:     true,
+		},
+		"fractional duration": {
+			profile: "step:10rps/1500ms",
+			err:     true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			phases, err := ParseProfile(test.profile)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error for %s, got %v", test.profile, phases)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(phases, test.expected) {
+				t.Errorf("unexpected phases\ngot : %v\nwant: %v", phases, test.expected)
+			}
+		})
+	}
+}
+
+func TestReadScenario(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scenario")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := map[string]struct {
+		scenario string
+		expected []Phase
+		err      bool
+	}{
+		"constant phases": {
+			scenario: `
+phases:
+- name: warmup
+  rps: 10
+  duration: 30s
+- rps: 100
+  duration: 1m
+`,
+			expected: []Phase{
+				{Name: "warmup", Steps: []Step{{10, 30}}},
+				{Name: "phase-2", Steps: []Step{{100, 60}}},
+			},
+		},
+		"profile phases": {
+			scenario: `
+phases:
+- name: ramp-up
+  profile: ramp:10-10rps/10s
+- name: burst
+  profile: step:50,100rps/5s
+`,
+			expected: []Phase{
+				{Name: "ramp-up", Steps: []Step{
+					{10, 1}, {10, 1}, {10, 1}, {10, 1}, {10, 1}, {10, 1}, {10, 1}, {10, 1}, {10, 1}, {10, 1},
+				}},
+				{Name: "burst/step-1", Steps: []Step{{50, 5}}},
+				{Name: "burst/step-2", Steps: []Step{{100, 5}}},
+			},
+		},
+		"unknown field": {
+			scenario: `
+phases:
+- name: warmup
+  rate: 10
+  duration: 30s
+`,
+			err: true,
+		},
+		"no phases": {
+			scenario: `phases: []`,
+			err:      true,
+		},
+		"missing rate": {
+			scenario: `
+phases:
+- duration: 30s
+`,
+			err: true,
+		},
+		"invalid profile": {
+			scenario: `
+phases:
+- profile: ramp:fast
+`,
+			err: true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(dir, name+".yaml")
+			err := ioutil.WriteFile(file, []byte(test.scenario), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			phases, err := ReadScenario(file)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error, got %v", phases)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(phases, test.expected) {
+				t.Errorf("unexpected phases\ngot : %v\nwant: %v", phases, test.expected)
+			}
+		})
+	}
+}
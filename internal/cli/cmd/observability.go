@@ -0,0 +1,284 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/canary"
+)
+
+const (
+	externalEndpointTimeout = 10 * time.Second
+
+	prometheusHealthPath = "/-/healthy"
+	grafanaHealthPath    = "/api/health"
+	jaegerHealthPath     = "/"
+)
+
+// externalEndpoints are the endpoints of existing observability components used instead of the bundled ones
+type externalEndpoints struct {
+	prometheusURL  string
+	grafanaURL     string
+	jaegerURL      string
+	tracingAddress string
+}
+
+func (e *externalEndpoints) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&e.prometheusURL, "prometheus-url", e.prometheusURL, "URL of an existing Prometheus to use instead of the bundled one")
+	flags.StringVar(&e.grafanaURL, "grafana-url", e.grafanaURL, "URL of an existing Grafana to use instead of the bundled one")
+	flags.StringVar(&e.jaegerURL, "jaeger-url", e.jaegerURL, "URL of the query UI of an existing Jaeger, requires --tracing-address")
+	flags.StringVar(&e.tracingAddress, "tracing-address", e.tracingAddress, "Address (host:port) of an existing Zipkin compatible collector to use instead of the bundled Jaeger")
+}
+
+// validate checks the format of the given endpoints
+func (e externalEndpoints) validate() error {
+	for name, rawURL := range map[string]string{
+		"prometheus-url": e.prometheusURL,
+		"grafana-url":    e.grafanaURL,
+		"jaeger-url":     e.jaegerURL,
+	} {
+		if rawURL == "" {
+			continue
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.NewWithDetails("invalid URL, an absolute http or https URL is required", "flag", name, "url", rawURL)
+		}
+	}
+
+	if e.tracingAddress != "" {
+		_, _, err := net.SplitHostPort(e.tracingAddress)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "invalid tracing address, host:port is required", "address", e.tracingAddress)
+		}
+	}
+
+	if e.jaegerURL != "" && e.tracingAddress == "" {
+		return errors.New("the --jaeger-url flag requires the address of the collector, use the --tracing-address flag")
+	}
+
+	return nil
+}
+
+// setValues disables the bundled components replaced by external endpoints
+func (e externalEndpoints) setValues(values *Values) {
+	if e.prometheusURL != "" {
+		u, _ := url.Parse(e.prometheusURL)
+		values.Prometheus.Enabled = false
+		values.Prometheus.URL = e.prometheusURL
+		values.Prometheus.Host = u.Hostname()
+	}
+
+	if e.grafanaURL != "" {
+		values.Grafana.Enabled = false
+		setApplicationEnv(values, "APP_GRAFANA", e.grafanaURL)
+	}
+
+	if e.tracingAddress != "" {
+		values.Tracing.Enabled = false
+		values.Tracing.Address = e.tracingAddress
+		if e.jaegerURL != "" {
+			setApplicationEnv(values, "APP_JAEGER", e.jaegerURL)
+		}
+	}
+}
+
+// canaryPrometheusURL returns the Prometheus URL the canary operator should use
+func (e externalEndpoints) canaryPrometheusURL() string {
+	if e.prometheusURL != "" {
+		return e.prometheusURL
+	}
+
+	return canary.DefaultPrometheusURL
+}
+
+func setApplicationEnv(values *Values, name, value string) {
+	if values.Application.Env == nil {
+		values.Application.Env = make(map[string]string)
+	}
+	values.Application.Env[name] = value
+}
+
+// check probes each of the given endpoints
+func (e externalEndpoints) check(config *rest.Config) ([]PreflightResult, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create k8s clientset")
+	}
+
+	results := make([]PreflightResult, 0)
+
+	for _, endpoint := range []struct {
+		name       string
+		url        string
+		healthPath string
+	}{
+		{"prometheus", e.prometheusURL, prometheusHealthPath},
+		{"grafana", e.grafanaURL, grafanaHealthPath},
+		{"jaeger", e.jaegerURL, jaegerHealthPath},
+	} {
+		if endpoint.url == "" {
+			continue
+		}
+		u, _ := url.Parse(endpoint.url)
+		results = append(results, endpointResult(endpoint.name, endpoint.url, probeURL(clientset, u, endpoint.healthPath)))
+	}
+
+	if e.tracingAddress != "" {
+		results = append(results, endpointResult("tracing", e.tracingAddress, probeAddress(clientset, e.tracingAddress)))
+	}
+
+	return results, nil
+}
+
+func endpointResult(name, endpoint string, err error) PreflightResult {
+	result := PreflightResult{
+		Check:   name + "-endpoint",
+		Result:  PreflightPass,
+		Message: fmt.Sprintf("%s is reachable", endpoint),
+	}
+	if err != nil {
+		result.Result = PreflightFail
+		result.Message = fmt.Sprintf("%s is not reachable: %s", endpoint, err)
+	}
+
+	return result
+}
+
+// probeURL sends a GET request to the health path under the URL, through the API server
+// if the URL points to a service in the cluster
+func probeURL(clientset kubernetes.Interface, u *url.URL, healthPath string) error {
+	path := strings.TrimSuffix(u.Path, "/") + healthPath
+
+	name, namespace, ok, err := serviceFromHost(clientset, u.Hostname())
+	if err != nil {
+		return err
+	}
+	if ok {
+		port, err := servicePort(clientset, name, namespace, u.Port())
+		if err != nil {
+			return err
+		}
+		_, err = clientset.CoreV1().Services(namespace).ProxyGet(u.Scheme, name, port, path, nil).DoRaw()
+		return err
+	}
+
+	probe := *u
+	probe.Path = path
+	httpClient := &http.Client{
+		Timeout: externalEndpointTimeout,
+	}
+	resp, err := httpClient.Get(probe.String())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("%s responded with %s", probe.String(), resp.Status)
+	}
+
+	return nil
+}
+
+// probeAddress checks that a TCP connection can be opened to the address, or that the service
+// in the cluster it points to exposes the port
+func probeAddress(clientset kubernetes.Interface, address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	name, namespace, ok, err := serviceFromHost(clientset, host)
+	if err != nil {
+		return err
+	}
+	if ok {
+		_, err = servicePort(clientset, name, namespace, port)
+		return err
+	}
+
+	conn, err := net.DialTimeout("tcp", address, externalEndpointTimeout)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// serviceFromHost returns the service name and namespace of in-cluster host names like name.namespace.svc
+// or name.namespace.svc.cluster.local, and of name.namespace if the namespace exists in the cluster
+func serviceFromHost(clientset kubernetes.Interface, host string) (string, string, bool, error) {
+	if net.ParseIP(host) != nil {
+		return "", "", false, nil
+	}
+
+	parts := strings.SplitN(host, ".", 3)
+	if len(parts) < 2 {
+		return "", "", false, nil
+	}
+	if len(parts) == 3 {
+		if parts[2] == "svc" || parts[2] == "svc.cluster.local" {
+			return parts[0], parts[1], true, nil
+		}
+		return "", "", false, nil
+	}
+
+	// a two label host name might as well be an external one, like example.com
+	_, err := clientset.CoreV1().Namespaces().Get(parts[1], metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return "", "", false, nil
+	}
+	if err != nil {
+		return "", "", false, errors.WrapIfWithDetails(err, "could not get namespace", "namespace", parts[1])
+	}
+
+	return parts[0], parts[1], true, nil
+}
+
+// servicePort checks whether the service exists and exposes the port
+func servicePort(clientset kubernetes.Interface, name, namespace, port string) (string, error) {
+	service, err := clientset.CoreV1().Services(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	if port == "" {
+		return "", nil
+	}
+
+	for _, p := range service.Spec.Ports {
+		if strconv.Itoa(int(p.Port)) == port {
+			return port, nil
+		}
+	}
+
+	return "", errors.Errorf("service %s/%s does not expose port %s", namespace, name, port)
+}
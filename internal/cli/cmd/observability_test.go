@@ -0,0 +1,84 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestServiceFromHost(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "monitoring"},
+	})
+
+	tests := map[string]struct {
+		host      string
+		name      string
+		namespace string
+		ok        bool
+	}{
+		"ip address": {
+			host: "10.0.0.1",
+		},
+		"single label": {
+			host: "prometheus",
+		},
+		"existing namespace": {
+			host:      "prometheus.monitoring",
+			name:      "prometheus",
+			namespace: "monitoring",
+			ok:        true,
+		},
+		"external two label host": {
+			host: "example.com",
+		},
+		"svc suffix": {
+			host:      "jaeger-collector.tracing.svc",
+			name:      "jaeger-collector",
+			namespace: "tracing",
+			ok:        true,
+		},
+		"cluster domain suffix": {
+			host:      "jaeger-collector.tracing.svc.cluster.local",
+			name:      "jaeger-collector",
+			namespace: "tracing",
+			ok:        true,
+		},
+		"external host with svc label": {
+			host: "grafana.monitoring.svc.example.com",
+		},
+		"external three label host": {
+			host: "grafana.example.com",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			serviceName, namespace, ok, err := serviceFromHost(clientset, test.host)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if serviceName != test.name || namespace != test.namespace || ok != test.ok {
+				t.Errorf("unexpected result for %s\ngot : %q %q %t\nwant: %q %q %t", test.host, serviceName, namespace, ok, test.name, test.namespace, test.ok)
+			}
+		})
+	}
+}
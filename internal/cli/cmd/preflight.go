@@ -0,0 +1,578 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	"istio.io/operator/pkg/object"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/canary"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/certmanager"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/demoapp"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
+	internalk8s "github.com/banzaicloud/backyards-cli/internal/k8s"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+)
+
+const (
+	PreflightPass = "pass"
+	PreflightWarn = "warn"
+	PreflightFail = "fail"
+
+	minKubernetesVersion       = "1.13.0"
+	maxTestedKubernetesVersion = "1.15.99"
+
+	defaultCanaryNamespace = "backyards-canary"
+)
+
+type preflightCommand struct {
+	cli cli.CLI
+}
+
+type PreflightOptions struct {
+	releaseName string
+	profile     string
+
+	installIstio       bool
+	installCertManager bool
+	installCanary      bool
+	installDemoapp     bool
+	demoNamespace      string
+
+	ingressOptions
+	externalEndpoints
+	tracingStorage
+}
+
+// PreflightResult is the outcome of a single preflight check
+type PreflightResult struct {
+	Check   string `json:"check"`
+	Result  string `json:"result"`
+	Message string `json:"message"`
+}
+
+// gvrNamespace identifies an access review target
+type gvrNamespace struct {
+	group     string
+	resource  string
+	namespace string
+}
+
+func NewPreflightOptions() *PreflightOptions {
+	return &PreflightOptions{
+		releaseName:   defaultReleaseName,
+		profile:       profileDefault,
+		demoNamespace: demoapp.GetNamespace(),
+	}
+}
+
+func NewPreflightCommand(cli cli.CLI, options *PreflightOptions) *cobra.Command {
+	c := &preflightCommand{
+		cli: cli,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "preflight [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Check whether the cluster meets the requirements of Backyards",
+		Long: `Check whether the cluster meets the requirements of Backyards.
+
+The command checks the Kubernetes server version, verifies that the current user
+is allowed to create every kind of resource the charts contain, detects conflicting
+Istio and cert-manager installations, checks that the required CRDs exist and looks
+for PodSecurityPolicy restrictions. It compares the CPU and memory requests of the
+new workloads with the capacity left on the schedulable nodes. The external Prometheus, Grafana and Jaeger
+endpoints, if given, are checked to be reachable.
+
+The checks are run automatically before install as well.`,
+		Example: `  # Check the requirements of the default install.
+  backyards preflight
+
+  # Check the requirements of installing every component.
+  backyards preflight --install-istio --install-cert-manager --install-canary
+
+  # Check the requirements of using an existing Prometheus.
+  backyards preflight --prometheus-url http://prometheus.monitoring:9090`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			err := options.externalEndpoints.validate()
+			if err != nil {
+				return err
+			}
+
+			return c.run(options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.releaseName, "release-name", options.releaseName, "Name of the release")
+	cmd.Flags().StringVar(&options.profile, "profile", options.profile, "Sizing profile of the components (minimal|default|production)")
+	cmd.Flags().BoolVar(&options.installIstio, "install-istio", options.installIstio, "Check the requirements of installing Istio mesh as well")
+	cmd.Flags().BoolVar(&options.installCertManager, "install-cert-manager", options.installCertManager, "Check the requirements of installing cert-manager as well")
+	cmd.Flags().BoolVar(&options.installCanary, "install-canary", options.installCanary, "Check the requirements of installing Canary feature as well")
+	cmd.Flags().BoolVar(&options.installDemoapp, "install-demoapp", options.installDemoapp, "Check the requirements of installing demo application as well")
+	cmd.Flags().StringVar(&options.demoNamespace, "demo-namespace", options.demoNamespace, "Namespace for demo application")
+	options.externalEndpoints.addFlags(cmd)
+
+	return cmd
+}
+
+func (c *preflightCommand) run(options *PreflightOptions) error {
+	cl, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	components, err := c.getComponentObjects(options)
+	if err != nil {
+		return err
+	}
+
+	objects := make(object.K8sObjects, 0)
+	for _, component := range components {
+		objects = append(objects, component.Objects...)
+	}
+
+	results := make([]PreflightResult, 0)
+
+	result, err := c.checkServerVersion(config)
+	if err != nil {
+		return err
+	}
+	results = append(results, result)
+
+	accessResults, err := c.checkAccess(cl, config, objects)
+	if err != nil {
+		return err
+	}
+	results = append(results, accessResults...)
+
+	result, err = c.checkIstioConflict(cl, options)
+	if err != nil {
+		return err
+	}
+	results = append(results, result)
+
+	result, err = c.checkCertManagerConflict(cl, options)
+	if err != nil {
+		return err
+	}
+	results = append(results, result)
+
+	result, err = c.checkPodSecurityPolicies(cl)
+	if err != nil {
+		return err
+	}
+	results = append(results, result)
+
+	result, err = c.checkCapacity(cl, components)
+	if err != nil {
+		return err
+	}
+	results = append(results, result)
+
+	endpointResults, err := options.externalEndpoints.check(config)
+	if err != nil {
+		return err
+	}
+	results = append(results, endpointResults...)
+
+	err = c.output(results)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.Result == PreflightFail {
+			return errors.New("preflight checks failed")
+		}
+	}
+
+	return nil
+}
+
+// getComponentObjects renders every resource which would be created by the install
+func (c *preflightCommand) getComponentObjects(options *PreflightOptions) ([]ComponentObjects, error) {
+	profile, err := getSizingProfile(options.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := getValues(options.releaseName, istio.IstioNamespace, func(values *Values) {
+		profile.apply(values)
+		options.ingressOptions.setValues(values)
+		options.externalEndpoints.setValues(values)
+		options.tracingStorage.setValues(values)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := getBackyardsObjects(values)
+	if err != nil {
+		return nil, err
+	}
+
+	components := []ComponentObjects{
+		{Name: ComponentBackyards, Objects: objects},
+	}
+
+	if options.installIstio {
+		istioObjects, err := istio.GetIstioOperatorObjects("istio-operator")
+		if err != nil {
+			return nil, err
+		}
+		istioCR, err := istio.GetIstioCR("")
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, ComponentObjects{Name: ComponentIstio, Objects: append(istioObjects, istioCR)})
+	}
+
+	if options.installCertManager {
+		certManagerObjects, err := certmanager.GetCertManagerObjects(certmanager.CertManagerNamespace)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, ComponentObjects{Name: ComponentCertManager, Objects: certManagerObjects})
+	}
+
+	if options.installCanary {
+		canaryObjects, err := canary.GetCanaryOperatorObjects("canary-operator", defaultCanaryNamespace, options.externalEndpoints.canaryPrometheusURL())
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, ComponentObjects{Name: ComponentCanary, Objects: canaryObjects})
+	}
+
+	if options.installDemoapp {
+		demoObjects, err := demoapp.GetBackyardsDemoObjects(options.demoNamespace)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, ComponentObjects{Name: ComponentDemoapp, Objects: demoObjects})
+	}
+
+	return components, nil
+}
+
+func (c *preflightCommand) checkServerVersion(config *rest.Config) (PreflightResult, error) {
+	result := PreflightResult{
+		Check: "kubernetes-version",
+	}
+
+	dc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return result, errors.WrapIf(err, "could not create discovery client")
+	}
+
+	info, err := dc.ServerVersion()
+	if err != nil {
+		return result, errors.WrapIf(err, "could not get server version")
+	}
+
+	serverVersion, err := version.ParseGeneric(info.GitVersion)
+	if err != nil {
+		return result, errors.WrapIfWithDetails(err, "could not parse server version", "version", info.GitVersion)
+	}
+
+	switch {
+	case serverVersion.LessThan(version.MustParseGeneric(minKubernetesVersion)):
+		result.Result = PreflightFail
+		result.Message = fmt.Sprintf("server version %s is older than the minimum supported %s", info.GitVersion, minKubernetesVersion)
+	case !serverVersion.LessThan(version.MustParseGeneric(maxTestedKubernetesVersion)):
+		result.Result = PreflightWarn
+		result.Message = fmt.Sprintf("server version %s is newer than the latest tested 1.15", info.GitVersion)
+	default:
+		result.Result = PreflightPass
+		result.Message = fmt.Sprintf("server version %s is supported", info.GitVersion)
+	}
+
+	return result, nil
+}
+
+// checkAccess verifies that the current user can create every kind of the rendered objects
+// and that the CRDs of the custom resources are either present or going to be installed
+func (c *preflightCommand) checkAccess(cl k8sclient.Client, config *rest.Config, objects object.K8sObjects) ([]PreflightResult, error) {
+	mapper, err := apiutil.NewDiscoveryRESTMapper(config)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create REST mapper")
+	}
+
+	// the resources of the kinds provided by the rendered CRDs are not served yet
+	type providedKind struct {
+		resource      string
+		clusterScoped bool
+	}
+	providedKinds := make(map[schema.GroupKind]providedKind)
+	for _, obj := range objects {
+		if obj.Kind != "CustomResourceDefinition" {
+			continue
+		}
+		spec, _ := obj.UnstructuredObject().Object["spec"].(map[string]interface{})
+		names, _ := spec["names"].(map[string]interface{})
+		group, _ := spec["group"].(string)
+		kind, _ := names["kind"].(string)
+		plural, _ := names["plural"].(string)
+		scope, _ := spec["scope"].(string)
+		providedKinds[schema.GroupKind{Group: group, Kind: kind}] = providedKind{
+			resource:      plural,
+			clusterScoped: scope == string(apiextensionsv1beta1.ClusterScoped),
+		}
+	}
+
+	targets := make(map[gvrNamespace]bool)
+	missingCRDs := make(map[string]bool)
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		target := gvrNamespace{
+			group:     gvk.Group,
+			namespace: obj.Namespace,
+		}
+
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		switch {
+		case err == nil:
+			target.resource = mapping.Resource.Resource
+			if mapping.Scope.Name() == k8smeta.RESTScopeNameRoot {
+				target.namespace = ""
+			}
+		case k8smeta.IsNoMatchError(err):
+			provided, ok := providedKinds[gvk.GroupKind()]
+			if !ok {
+				missingCRDs[gvk.GroupKind().String()] = true
+				continue
+			}
+			target.resource = provided.resource
+			if provided.clusterScoped {
+				target.namespace = ""
+			}
+		default:
+			return nil, errors.WrapIfWithDetails(err, "could not map kind", "kind", gvk.String())
+		}
+
+		targets[target] = true
+	}
+
+	denied := make([]string, 0)
+	for target := range targets {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:      "create",
+					Group:     target.group,
+					Resource:  target.resource,
+					Namespace: target.namespace,
+				},
+			},
+		}
+		err = cl.Create(context.Background(), review)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not create access review", "resource", target.resource)
+		}
+		if !review.Status.Allowed {
+			denied = append(denied, target.String())
+		}
+	}
+
+	rbac := PreflightResult{
+		Check:   "rbac",
+		Result:  PreflightPass,
+		Message: fmt.Sprintf("allowed to create all %d kinds of resources", len(targets)),
+	}
+	if len(denied) > 0 {
+		sort.Strings(denied)
+		rbac.Result = PreflightFail
+		rbac.Message = "not allowed to create " + strings.Join(denied, ", ")
+	}
+
+	crds := PreflightResult{
+		Check:   "required-crds",
+		Result:  PreflightPass,
+		Message: "every required CRD is present or going to be installed",
+	}
+	if len(missingCRDs) > 0 {
+		crds.Result = PreflightFail
+		crds.Message = "missing CRDs for " + strings.Join(sortedKeys(missingCRDs), ", ")
+	}
+
+	return []PreflightResult{rbac, crds}, nil
+}
+
+func (t gvrNamespace) String() string {
+	resource := t.resource
+	if t.group != "" {
+		resource += "." + t.group
+	}
+	if t.namespace != "" {
+		resource += " in " + t.namespace
+	}
+	return resource
+}
+
+// checkIstioConflict looks for Istio control planes which are not managed by the Banzai Cloud Istio operator
+func (c *preflightCommand) checkIstioConflict(cl k8sclient.Client, options *PreflightOptions) (PreflightResult, error) {
+	result := PreflightResult{
+		Check:   "istio",
+		Result:  PreflightPass,
+		Message: "no conflicting Istio installation found",
+	}
+
+	var deployments appsv1.DeploymentList
+	err := cl.List(context.Background(), &deployments, client.MatchingLabels(map[string]string{
+		"istio": "pilot",
+	}))
+	if err != nil {
+		return result, errors.WrapIf(err, "could not list deployments")
+	}
+
+	foreign := make([]string, 0)
+	for _, deployment := range deployments.Items {
+		managed := false
+		for _, ref := range deployment.OwnerReferences {
+			if ref.Kind == "Istio" {
+				managed = true
+			}
+		}
+		if !managed {
+			foreign = append(foreign, deployment.Namespace+"/"+deployment.Name)
+		}
+	}
+
+	if len(foreign) > 0 {
+		result.Result = PreflightWarn
+		if options.installIstio {
+			result.Result = PreflightFail
+		}
+		result.Message = "Istio control plane not managed by the Istio operator found: " + strings.Join(foreign, ", ")
+	}
+
+	return result, nil
+}
+
+// checkCertManagerConflict looks for cert-manager installations which were not installed by Backyards
+// and are not compatible with it
+func (c *preflightCommand) checkCertManagerConflict(cl k8sclient.Client, options *PreflightOptions) (PreflightResult, error) {
+	result := PreflightResult{
+		Check:   "cert-manager",
+		Result:  PreflightPass,
+		Message: "no conflicting cert-manager installation found",
+	}
+
+	if !options.installCertManager {
+		return result, nil
+	}
+
+	installation, err := certmanager.DetectInstallation(cl)
+	if err != nil {
+		return result, err
+	}
+	if installation != nil && !installation.Managed {
+		err = certmanager.CheckCompatibility(cl, installation)
+		if err != nil {
+			result.Result = PreflightFail
+			result.Message = err.Error()
+			return result, nil
+		}
+		result.Message = fmt.Sprintf("compatible %s will be reused", installation)
+		return result, nil
+	}
+
+	var ns corev1.Namespace
+	err = cl.Get(context.Background(), types.NamespacedName{Name: certmanager.CertManagerNamespace}, &ns)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return result, errors.WrapIf(err, "could not get cert-manager namespace")
+	}
+	if err == nil && !hasCLIVersionLabel(ns) {
+		result.Result = PreflightFail
+		result.Message = fmt.Sprintf("cert-manager installed by other means found: namespace %s not managed by Backyards", certmanager.CertManagerNamespace)
+	}
+
+	return result, nil
+}
+
+func hasCLIVersionLabel(ns corev1.Namespace) bool {
+	_, ok := ns.Labels[internalk8s.CLIVersionLabel]
+	return ok
+}
+
+func (c *preflightCommand) checkPodSecurityPolicies(cl k8sclient.Client) (PreflightResult, error) {
+	result := PreflightResult{
+		Check:   "pod-security-policy",
+		Result:  PreflightPass,
+		Message: "no PodSecurityPolicy found",
+	}
+
+	var policies policyv1beta1.PodSecurityPolicyList
+	err := cl.List(context.Background(), &policies)
+	if k8smeta.IsNoMatchError(err) {
+		return result, nil
+	}
+	if err != nil {
+		return result, errors.WrapIf(err, "could not list pod security policies")
+	}
+
+	if len(policies.Items) > 0 {
+		result.Result = PreflightWarn
+		result.Message = fmt.Sprintf("%d PodSecurityPolicies found, pods are only admitted if their service accounts may use one of them", len(policies.Items))
+	}
+
+	return result, nil
+}
+
+func (c *preflightCommand) output(results []PreflightResult) error {
+	ctx := &output.Context{
+		Out:     c.cli.Out(),
+		Color:   c.cli.Color(),
+		Format:  c.cli.OutputFormat(),
+		Fields:  []string{"Check", "Result", "Message"},
+		Headers: []string{"Check", "Result", "Message"},
+	}
+
+	err := output.Output(ctx, results)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
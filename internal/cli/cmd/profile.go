@@ -0,0 +1,243 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+)
+
+const (
+	profileMinimal    = "minimal"
+	profileDefault    = "default"
+	profileProduction = "production"
+)
+
+// sizingProfile is a coherent set of resources, replica counts and scrape settings of the Backyards components
+type sizingProfile struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	apply func(values *Values)
+}
+
+var sizingProfiles = []sizingProfile{
+	{
+		Name:        profileMinimal,
+		Description: "Single replicas with small requests for local clusters like kind or minikube",
+		apply: func(values *Values) {
+			values.ReplicaCount = 1
+			values.Autoscaling.Enabled = false
+			values.Resources = resourceRequirements("50m", "64Mi", "200m", "256Mi")
+			values.Prometheus.Resources = resourceRequirements("50m", "128Mi", "500m", "1Gi")
+			values.Prometheus.Config.Global.ScrapeInterval = "30s"
+			values.Prometheus.Config.Global.ScrapeTimeout = "10s"
+			values.Prometheus.Config.Global.EvaluationInterval = "30s"
+			values.Grafana.Resources = resourceRequirements("20m", "64Mi", "200m", "256Mi")
+			values.Tracing.Jaeger.Resources = resourceRequirements("20m", "64Mi", "500m", "512Mi")
+			values.Tracing.Jaeger.Memory.MaxTraces = "10000"
+		},
+	},
+	{
+		Name:        profileDefault,
+		Description: "The default settings of the chart",
+		apply:       func(values *Values) {},
+	},
+	{
+		Name:        profileProduction,
+		Description: "Multiple API replicas with autoscaling and larger limits for highly available installs",
+		apply: func(values *Values) {
+			values.ReplicaCount = 2
+			values.Autoscaling.Enabled = true
+			values.Autoscaling.MinReplicas = 2
+			values.Autoscaling.MaxReplicas = 5
+			values.Autoscaling.TargetCPUUtilizationPercentage = 70
+			values.Autoscaling.TargetMemoryUtilizationPercentage = 70
+			values.Resources = resourceRequirements("200m", "256Mi", "1000m", "1Gi")
+			values.Prometheus.Resources = resourceRequirements("500m", "2Gi", "4000m", "16Gi")
+			values.Prometheus.Config.Global.ScrapeInterval = "15s"
+			values.Prometheus.Config.Global.ScrapeTimeout = "10s"
+			values.Prometheus.Config.Global.EvaluationInterval = "15s"
+			values.Grafana.Resources = resourceRequirements("100m", "128Mi", "1000m", "1Gi")
+			values.Tracing.Jaeger.Resources = resourceRequirements("200m", "512Mi", "2000m", "8Gi")
+			values.Tracing.Jaeger.Memory.MaxTraces = "100000"
+		},
+	},
+}
+
+// ProfileComponent is the sizing of a component in a profile
+type ProfileComponent struct {
+	Component     string `json:"component"`
+	Replicas      string `json:"replicas"`
+	CPURequest    string `json:"cpuRequest"`
+	CPULimit      string `json:"cpuLimit"`
+	MemoryRequest string `json:"memoryRequest"`
+	MemoryLimit   string `json:"memoryLimit"`
+	Settings      string `json:"settings,omitempty"`
+}
+
+func getSizingProfile(name string) (sizingProfile, error) {
+	for _, profile := range sizingProfiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+
+	return sizingProfile{}, errors.NewWithDetails("unknown profile", "profile", name, "supported", sizingProfileNames())
+}
+
+func sizingProfileNames() []string {
+	names := make([]string, len(sizingProfiles))
+	for i, profile := range sizingProfiles {
+		names[i] = profile.Name
+	}
+
+	return names
+}
+
+func resourceRequirements(cpuRequest, memoryRequest, cpuLimit, memoryLimit string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpuRequest),
+			corev1.ResourceMemory: resource.MustParse(memoryRequest),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpuLimit),
+			corev1.ResourceMemory: resource.MustParse(memoryLimit),
+		},
+	}
+}
+
+func NewProfileCommand(cli cli.CLI) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Show the sizing profiles of the Backyards components",
+	}
+
+	cmd.AddCommand(
+		newProfileListCommand(cli),
+		newProfileShowCommand(cli),
+	)
+
+	return cmd
+}
+
+func newProfileListCommand(cli cli.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Args:  cobra.NoArgs,
+		Short: "List the sizing profiles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			ctx := &output.Context{
+				Out:     cli.Out(),
+				Color:   cli.Color(),
+				Format:  cli.OutputFormat(),
+				Fields:  []string{"Name", "Description"},
+				Headers: []string{"Name", "Description"},
+			}
+
+			err := output.Output(ctx, sizingProfiles)
+			if err != nil {
+				return errors.WrapIf(err, "could not produce output")
+			}
+
+			return nil
+		},
+	}
+}
+
+func newProfileShowCommand(cli cli.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:       "show [name]",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: sizingProfileNames(),
+		Short:     "Show the resources, replicas and settings of a sizing profile",
+		Example: `  # Show the values of the profile for local clusters.
+  backyards profile show minimal`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			profile, err := getSizingProfile(args[0])
+			if err != nil {
+				return err
+			}
+
+			values, err := getValues(defaultReleaseName, istio.IstioNamespace, profile.apply)
+			if err != nil {
+				return err
+			}
+
+			ctx := &output.Context{
+				Out:     cli.Out(),
+				Color:   cli.Color(),
+				Format:  cli.OutputFormat(),
+				Fields:  []string{"Component", "Replicas", "CPURequest", "CPULimit", "MemoryRequest", "MemoryLimit", "Settings"},
+				Headers: []string{"Component", "Replicas", "CPU request", "CPU limit", "Memory request", "Memory limit", "Settings"},
+			}
+
+			err = output.Output(ctx, getProfileComponents(values))
+			if err != nil {
+				return errors.WrapIf(err, "could not produce output")
+			}
+
+			return nil
+		},
+	}
+}
+
+// getProfileComponents summarizes the sizing related values of the components
+func getProfileComponents(values Values) []ProfileComponent {
+	replicas := fmt.Sprintf("%d", values.ReplicaCount)
+	autoscaling := "autoscaling disabled"
+	if values.Autoscaling.Enabled {
+		replicas = fmt.Sprintf("%d-%d", values.Autoscaling.MinReplicas, values.Autoscaling.MaxReplicas)
+		autoscaling = fmt.Sprintf("autoscaling at %d%% CPU, %d%% memory", values.Autoscaling.TargetCPUUtilizationPercentage, values.Autoscaling.TargetMemoryUtilizationPercentage)
+	}
+
+	component := func(name, replicas string, resources corev1.ResourceRequirements, settings string) ProfileComponent {
+		return ProfileComponent{
+			Component:     name,
+			Replicas:      replicas,
+			CPURequest:    resources.Requests.Cpu().String(),
+			CPULimit:      resources.Limits.Cpu().String(),
+			MemoryRequest: resources.Requests.Memory().String(),
+			MemoryLimit:   resources.Limits.Memory().String(),
+			Settings:      settings,
+		}
+	}
+
+	global := values.Prometheus.Config.Global
+
+	return []ProfileComponent{
+		component("backyards", replicas, values.Resources, autoscaling),
+		// the web container uses the resources of the API container
+		component("web", replicas, values.Resources, ""),
+		component("prometheus", "1", values.Prometheus.Resources, fmt.Sprintf("scrape every %s, evaluate every %s", global.ScrapeInterval, global.EvaluationInterval)),
+		component("grafana", "1", values.Grafana.Resources, ""),
+		component("jaeger", "1", values.Tracing.Jaeger.Resources, fmt.Sprintf("max %s traces in memory", values.Tracing.Jaeger.Memory.MaxTraces)),
+	}
+}
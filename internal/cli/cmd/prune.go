@@ -0,0 +1,198 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"emperror.dev/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"istio.io/operator/pkg/object"
+
+	internalk8s "github.com/banzaicloud/backyards-cli/internal/k8s"
+	"github.com/banzaicloud/backyards-cli/internal/release"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/helm"
+	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+)
+
+type pruneCommand struct {
+	cli cli.CLI
+}
+
+type PruneOptions struct {
+	releaseName string
+	dryRun      bool
+}
+
+// PrunableResource is an object applied by an earlier revision of the release which is not part of the latest one
+type PrunableResource struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func NewPruneCommand(cli cli.CLI) *cobra.Command {
+	c := &pruneCommand{
+		cli: cli,
+	}
+	options := &PruneOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "prune [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Remove resources of earlier Backyards revisions which are not part of the current one",
+		Long: `Remove resources of earlier Backyards revisions which are not part of the current one.
+
+Every revision of the release records the objects it applied. The command deletes
+the objects which were applied by an earlier revision, are still labeled as
+managed by the CLI, but are not part of the latest revision, like leftovers of
+older chart versions.
+
+Objects which were never applied by the release, like restored mesh configuration,
+attached remote clusters or the resources of the other components, are not touched.
+
+It can only list the objects to be deleted with the '--dry-run' option.`,
+		Example: `  # List the orphaned resources.
+  backyards prune --dry-run
+
+  # Delete the orphaned resources.
+  backyards prune`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return c.run(options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.releaseName, "release-name", defaultReleaseName, "Name of the release")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", options.dryRun, "Only list the resources which would be deleted")
+
+	return cmd
+}
+
+func (c *pruneCommand) run(options *PruneOptions) error {
+	client, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	records, err := release.NewStore(client, viper.GetString("backyards.namespace")).List(options.releaseName)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		log.Infof("no revisions recorded for release %s, there is nothing to prune", options.releaseName)
+		return c.output(nil)
+	}
+
+	owned := make([]string, 0)
+	var desired []string
+	for _, record := range records {
+		desired, err = getRecordedObjectKeys(record)
+		if err != nil {
+			return err
+		}
+		owned = append(owned, desired...)
+	}
+
+	live, err := k8s.ListObjectsWithLabelSelector(config, internalk8s.CLIVersionLabel)
+	if err != nil {
+		return err
+	}
+
+	orphans := orphanedObjects(live, owned, desired)
+	orphans.Sort(helm.UninstallObjectOrder())
+
+	if options.dryRun || len(orphans) == 0 {
+		return c.output(orphans)
+	}
+
+	err = k8s.DeleteResources(client, c.cli.LabelManager(), c.cli.Logger(), orphans, k8s.WaitForResourceConditions(config, c.cli.WaitOptions(), k8s.NonExistsConditionCheck))
+	if err != nil {
+		return errors.WrapIf(err, "could not prune k8s resources")
+	}
+
+	return nil
+}
+
+// getRecordedObjectKeys returns the keys of the objects applied by the revision, rendering them from
+// the recorded values for revisions which were saved without the object keys
+func getRecordedObjectKeys(record *release.Record) ([]string, error) {
+	if len(record.Objects) > 0 {
+		return record.Objects, nil
+	}
+
+	_, objects, err := getRecordedObjects(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return objectKeys(objects), nil
+}
+
+// orphanedObjects returns the live objects which were owned by the release but are not desired anymore
+func orphanedObjects(live object.K8sObjects, owned, desired []string) object.K8sObjects {
+	ownedKeys := make(map[string]bool, len(owned))
+	for _, key := range owned {
+		ownedKeys[key] = true
+	}
+	desiredKeys := make(map[string]bool, len(desired))
+	for _, key := range desired {
+		desiredKeys[key] = true
+	}
+
+	orphans := make(object.K8sObjects, 0)
+	for _, obj := range live {
+		if ownedKeys[obj.Hash()] && !desiredKeys[obj.Hash()] {
+			orphans = append(orphans, obj)
+		}
+	}
+
+	return orphans
+}
+
+func (c *pruneCommand) output(objects object.K8sObjects) error {
+	resources := make([]PrunableResource, len(objects))
+	for i, obj := range objects {
+		resources[i] = PrunableResource{
+			Kind:      obj.Kind,
+			Namespace: obj.Namespace,
+			Name:      obj.Name,
+		}
+	}
+
+	ctx := &output.Context{
+		Out:     c.cli.Out(),
+		Color:   c.cli.Color(),
+		Format:  c.cli.OutputFormat(),
+		Fields:  []string{"Kind", "Namespace", "Name"},
+		Headers: []string{"Kind", "Namespace", "Name"},
+	}
+
+	err := output.Output(ctx, resources)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/operator/pkg/object"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newTestObject(kind, namespace, name string) *object.K8sObject {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+
+	return object.NewK8sObject(obj, nil, nil)
+}
+
+func TestOrphanedObjects(t *testing.T) {
+	live := object.K8sObjects{
+		newTestObject("ConfigMap", "backyards-system", "current"),
+		newTestObject("ConfigMap", "backyards-system", "leftover"),
+		newTestObject("ClusterRole", "", "leftover"),
+		newTestObject("VirtualService", "default", "restored"),
+		newTestObject("Namespace", "", "backyards-demo-2"),
+	}
+
+	tests := map[string]struct {
+		owned    []string
+		desired  []string
+		expected []string
+	}{
+		"nothing owned": {
+			owned:    nil,
+			desired:  nil,
+			expected: []string{},
+		},
+		"everything desired": {
+			owned:    []string{"ConfigMap:backyards-system:current", "ConfigMap:backyards-system:leftover"},
+			desired:  []string{"ConfigMap:backyards-system:current", "ConfigMap:backyards-system:leftover"},
+			expected: []string{},
+		},
+		"leftovers of earlier revisions": {
+			owned:    []string{"ConfigMap:backyards-system:current", "ConfigMap:backyards-system:leftover", "ClusterRole::leftover"},
+			desired:  []string{"ConfigMap:backyards-system:current"},
+			expected: []string{"ConfigMap:backyards-system:leftover", "ClusterRole::leftover"},
+		},
+		"owned objects which are not desired anymore": {
+			owned:    []string{"ConfigMap:backyards-system:current"},
+			desired:  []string{},
+			expected: []string{"ConfigMap:backyards-system:current"},
+		},
+		"restored objects never applied by the release": {
+			// the restored VirtualService is live and labeled, but none of the revisions owns it
+			owned:    []string{"ConfigMap:backyards-system:current", "ConfigMap:backyards-system:leftover"},
+			desired:  []string{"ConfigMap:backyards-system:current"},
+			expected: []string{"ConfigMap:backyards-system:leftover"},
+		},
+		"owned objects which are gone": {
+			owned:    []string{"ConfigMap:backyards-system:deleted"},
+			desired:  []string{},
+			expected: []string{},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			if got := objectKeys(orphanedObjects(live, test.owned, test.desired)); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("unexpected orphans\ngot : %v\nwant: %v", got, test.expected)
+			}
+		})
+	}
+}
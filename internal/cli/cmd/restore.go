@@ -0,0 +1,401 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"istio.io/operator/pkg/object"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	internalk8s "github.com/banzaicloud/backyards-cli/internal/k8s"
+	"github.com/banzaicloud/backyards-cli/internal/release"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/helm"
+	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+)
+
+const (
+	restoreSkip         = "Skip this resource"
+	restoreSkipAll      = "Skip all"
+	restoreOverwrite    = "Overwrite this resource"
+	restoreOverwriteAll = "Overwrite all"
+)
+
+type restoreCommand struct {
+	cli cli.CLI
+}
+
+type RestoreOptions struct {
+	file       string
+	namespaces []string
+	dryRun     bool
+}
+
+// backup is the content of a backup archive
+type backup struct {
+	metadata BackupMetadata
+	record   *release.Record
+	objects  object.K8sObjects
+}
+
+func NewRestoreCommand(cli cli.CLI) *cobra.Command {
+	c := &restoreCommand{
+		cli: cli,
+	}
+	options := &RestoreOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "restore [file] [flags]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Restore the mesh configuration from an archive",
+		Long: `Restore the mesh configuration from an archive created by 'backyards backup'.
+
+The command applies the Istio custom resource, Backyards with the archived release
+settings and the Istio networking and security objects, in this order. Missing
+namespaces of the objects are created. If namespaces are selected, only their
+networking and security objects are restored. The Istio operator must already be installed,
+e.g. with 'backyards istio install'.
+
+The networking and security objects are not marked as managed by the CLI. Existing
+objects are only overwritten after an interactive confirmation, otherwise they are
+skipped and reported.
+
+It can only list the objects to be applied with the '--dry-run' option.`,
+		Example: `  # List the objects in the archive.
+  backyards restore mesh.tar.gz --dry-run
+
+  # Restore the mesh configuration of a namespace only.
+  backyards restore mesh.tar.gz --namespaces backyards-demo`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if len(args) > 0 {
+				options.file = args[0]
+			}
+
+			return c.run(options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.file, "file", "f", defaultBackupFile, "Path of the archive to restore")
+	cmd.Flags().StringSliceVar(&options.namespaces, "namespaces", options.namespaces, "Namespaces to restore the mesh configuration of (defaults to every archived namespace)")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", options.dryRun, "Only list the objects which would be applied")
+
+	return cmd
+}
+
+func (c *restoreCommand) run(options *RestoreOptions) error {
+	b, err := readBackup(c.cli.Logger(), options.file)
+	if err != nil {
+		return err
+	}
+
+	c.cli.Logger().Debugf("archive created at %s by CLI version %s", b.metadata.Timestamp, b.metadata.CLIVersion)
+
+	istioObjects, meshObjects := splitBackupObjects(b.objects, options.namespaces)
+	// restoring a subset of namespaces leaves the control plane and Backyards untouched
+	if len(options.namespaces) > 0 {
+		istioObjects = nil
+		b.record = nil
+	}
+
+	if options.dryRun {
+		if b.record != nil {
+			c.cli.Logger().Infof("Backyards would be restored with the settings of revision %d of release %s", b.record.Revision, b.record.Name)
+		}
+		return outputBackupResources(c.cli, append(istioObjects, meshObjects...))
+	}
+
+	client, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	err = k8s.ApplyResources(client, c.cli.LabelManager(), c.cli.Logger(), istioObjects)
+	if err != nil {
+		return errors.WrapIf(err, "could not restore Istio CR")
+	}
+
+	if b.record != nil {
+		values, objects, err := getRecordedObjects(b.record)
+		if err != nil {
+			return err
+		}
+		objects.Sort(helm.InstallObjectOrder())
+
+		err = k8s.ApplyResources(client, c.cli.LabelManager(), c.cli.Logger(), objects)
+		if err != nil {
+			return errors.WrapIf(err, "could not restore Backyards")
+		}
+
+		err = k8s.WaitForResourcesConditions(config, k8s.NamesWithGVKFromK8sObjects(objectsToWaitFor(c.cli.Logger(), values, objects)), c.cli.WaitOptions(), k8s.ExistsConditionCheck, k8s.ReadyConditionCheck)
+		if err != nil {
+			return err
+		}
+
+		err = release.NewStore(client, viper.GetString("backyards.namespace")).Save(&release.Record{
+			Name:       b.record.Name,
+			CLIVersion: c.cli.GetRootCommand().Version,
+			Values:     b.record.Values,
+			Options:    b.record.Options,
+			Objects:    objectKeys(objects),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ensureNamespaces(client, c.cli.Logger(), meshObjects)
+	if err != nil {
+		return err
+	}
+
+	// the mesh configuration belongs to the users, so it is restored without marking it as managed by the CLI
+	labelManager := &restoreLabelManager{
+		interactive: c.cli.InteractiveTerminal(),
+	}
+	err = k8s.ApplyResources(client, labelManager, c.cli.Logger(), meshObjects)
+	if err != nil {
+		return errors.WrapIf(err, "could not restore mesh configuration")
+	}
+	if len(labelManager.skipped) > 0 {
+		c.cli.Logger().Warnf("the following existing objects were not restored: %s", strings.Join(labelManager.skipped, ", "))
+		c.cli.Logger().Warn("delete them or run the restore interactively to overwrite them")
+	}
+
+	c.cli.Logger().Infof("mesh configuration restored from %s", options.file)
+
+	return nil
+}
+
+func readBackup(logger log.FieldLogger, file string) (*backup, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not open archive", "file", file)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not read archive", "file", file)
+	}
+	defer gr.Close()
+
+	b := &backup{
+		objects: make(object.K8sObjects, 0),
+	}
+	metadataFound := false
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not read archive", "file", file)
+		}
+
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not read archive entry", "name", header.Name)
+		}
+
+		switch {
+		case header.Name == backupMetadataFile:
+			err = json.Unmarshal(content, &b.metadata)
+			metadataFound = true
+		case header.Name == backupReleaseFile:
+			b.record = &release.Record{}
+			err = json.Unmarshal(content, b.record)
+		case strings.HasPrefix(header.Name, backupResourcesPath+"/"):
+			obj := &unstructured.Unstructured{}
+			err = yaml.Unmarshal(content, &obj.Object)
+			if err == nil {
+				b.objects = append(b.objects, object.NewK8sObject(obj, nil, nil))
+			}
+		default:
+			logger.Debugf("skipping unknown archive entry %s", header.Name)
+		}
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not parse archive entry", "name", header.Name)
+		}
+	}
+
+	if !metadataFound {
+		return nil, errors.NewWithDetails("not a Backyards backup archive", "file", file)
+	}
+
+	return b, nil
+}
+
+// splitBackupObjects separates the Istio CR from the mesh configuration objects of the given namespaces
+// and sorts the latter into the order they can be applied in
+func splitBackupObjects(objects object.K8sObjects, namespaces []string) (object.K8sObjects, object.K8sObjects) {
+	selected := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		selected[namespace] = true
+	}
+
+	order := make(map[string]int, len(backupKinds))
+	for i, kind := range backupKinds {
+		order[kind] = i
+	}
+
+	istioObjects := make(object.K8sObjects, 0)
+	meshObjects := make(object.K8sObjects, 0)
+	for _, obj := range objects {
+		if obj.Kind == "Istio" {
+			istioObjects = append(istioObjects, obj)
+			continue
+		}
+		if len(selected) > 0 && !selected[obj.Namespace] {
+			continue
+		}
+		meshObjects = append(meshObjects, obj)
+	}
+
+	sort.SliceStable(meshObjects, func(i, j int) bool {
+		return order[meshObjects[i].Kind] < order[meshObjects[j].Kind]
+	})
+
+	return istioObjects, meshObjects
+}
+
+// restoreLabelManager applies objects without the label of the CLI and asks before overwriting existing
+// objects which are not managed by the CLI, skipping them when it cannot ask
+type restoreLabelManager struct {
+	interactive  bool
+	overwriteAll bool
+	skipAll      bool
+	skipped      []string
+}
+
+func (lm *restoreLabelManager) CheckLabelsBeforeCreate(desired *unstructured.Unstructured) (bool, error) {
+	removeCLIVersionLabel(desired)
+	return false, nil
+}
+
+func (lm *restoreLabelManager) CheckLabelsBeforeUpdate(actual, desired *unstructured.Unstructured) (bool, error) {
+	// objects managed by the CLI, like the ones of the Backyards chart in archives of earlier versions,
+	// keep the label so that uninstall, prune and rollback still handle them
+	if version, ok := actual.GetLabels()[internalk8s.CLIVersionLabel]; ok {
+		labels := desired.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[internalk8s.CLIVersionLabel] = version
+		desired.SetLabels(labels)
+		return false, nil
+	}
+
+	removeCLIVersionLabel(desired)
+	if lm.overwriteAll {
+		return false, nil
+	}
+
+	name := k8s.GetFormattedName(actual)
+	if lm.skipAll || !lm.interactive {
+		lm.skipped = append(lm.skipped, name)
+		return true, nil
+	}
+
+	var r string
+	err := survey.AskOne(&survey.Select{
+		Message: fmt.Sprintf("Existing resource %s would be overwritten", name),
+		Options: []string{restoreSkip, restoreSkipAll, restoreOverwrite, restoreOverwriteAll},
+	}, &r)
+	if err != nil {
+		return true, errors.WrapIf(err, "could not ask for confirmation")
+	}
+
+	switch r {
+	case restoreOverwriteAll:
+		lm.overwriteAll = true
+		return false, nil
+	case restoreOverwrite:
+		return false, nil
+	case restoreSkipAll:
+		lm.skipAll = true
+	}
+	lm.skipped = append(lm.skipped, name)
+
+	return true, nil
+}
+
+func (lm *restoreLabelManager) CheckLabelsBeforeDelete(actual *unstructured.Unstructured) (bool, error) {
+	return true, nil
+}
+
+func removeCLIVersionLabel(obj *unstructured.Unstructured) {
+	labels := obj.GetLabels()
+	if _, ok := labels[internalk8s.CLIVersionLabel]; !ok {
+		return
+	}
+	delete(labels, internalk8s.CLIVersionLabel)
+	obj.SetLabels(labels)
+}
+
+// ensureNamespaces creates the missing namespaces of the objects without marking them as managed
+func ensureNamespaces(client k8sclient.Client, logger log.FieldLogger, objects object.K8sObjects) error {
+	seen := make(map[string]bool)
+	for _, obj := range objects {
+		if obj.Namespace == "" || seen[obj.Namespace] {
+			continue
+		}
+		seen[obj.Namespace] = true
+
+		err := client.Create(context.Background(), &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: obj.Namespace,
+			},
+		})
+		if k8serrors.IsAlreadyExists(err) {
+			continue
+		}
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not create namespace", "namespace", obj.Namespace)
+		}
+		logger.Infof("namespace %s created", obj.Namespace)
+	}
+
+	return nil
+}
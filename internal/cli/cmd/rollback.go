@@ -0,0 +1,225 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"emperror.dev/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"istio.io/operator/pkg/object"
+
+	clierrors "github.com/banzaicloud/backyards-cli/internal/errors"
+	internalk8s "github.com/banzaicloud/backyards-cli/internal/k8s"
+	"github.com/banzaicloud/backyards-cli/internal/release"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/helm"
+	"github.com/banzaicloud/backyards-cli/pkg/images"
+	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+)
+
+type rollbackCommand struct {
+	cli cli.CLI
+}
+
+type RollbackOptions struct {
+	releaseName   string
+	revision      int
+	dumpResources bool
+}
+
+func NewRollbackCommand(cli cli.CLI) *cobra.Command {
+	c := &rollbackCommand{
+		cli: cli,
+	}
+	options := &RollbackOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rollback [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Roll back Backyards to a previous install revision",
+		Long: `Roll back Backyards to a previous install revision.
+
+Every successful install is recorded as a new revision of the release.
+The command re-renders the charts with the values of the selected revision,
+applies them and removes the objects that were not part of that revision.
+
+It can only dump the applicable resources with the '--dump-resources' option.`,
+		Example: `  # Roll back to the previous revision.
+  backyards rollback
+
+  # Roll back to a specific revision.
+  backyards rollback --revision 2`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return c.run(options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.releaseName, "release-name", defaultReleaseName, "Name of the release")
+	cmd.Flags().IntVar(&options.revision, "revision", 0, "Revision to roll back to (defaults to the previous revision)")
+	cmd.Flags().BoolVarP(&options.dumpResources, "dump-resources", "d", options.dumpResources, "Dump resources to stdout instead of applying them")
+
+	return cmd
+}
+
+func (c *rollbackCommand) run(options *RollbackOptions) error {
+	client, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	config, err := c.cli.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	store := release.NewStore(client, viper.GetString("backyards.namespace"))
+
+	current, err := store.Latest(options.releaseName)
+	if err != nil {
+		if clierrors.IsNotFound(err) {
+			return errors.NewWithDetails("no revisions recorded for release", "name", options.releaseName)
+		}
+		return err
+	}
+
+	revision := options.revision
+	if revision == 0 {
+		revision = current.Revision - 1
+	}
+	if revision == current.Revision {
+		return errors.NewWithDetails("release is already at the requested revision", "revision", revision)
+	}
+
+	target, err := store.Get(options.releaseName, revision)
+	if err != nil {
+		if clierrors.IsNotFound(err) {
+			return errors.NewWithDetails("revision not found", "name", options.releaseName, "revision", revision)
+		}
+		return err
+	}
+
+	targetValues, targetObjects, err := getRecordedObjects(target)
+	if err != nil {
+		return err
+	}
+	targetObjects.Sort(helm.InstallObjectOrder())
+
+	if options.dumpResources {
+		yaml, err := targetObjects.YAMLManifest()
+		if err != nil {
+			return errors.WrapIf(err, "could not render YAML manifest")
+		}
+		fmt.Fprint(c.cli.Out(), yaml)
+		return nil
+	}
+
+	currentKeys, err := getRecordedObjectKeys(current)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("rolling back release %s from revision %d to revision %d", options.releaseName, current.Revision, target.Revision)
+
+	ic := &installCommand{cli: c.cli}
+	err = ic.setTracingAddress(targetValues)
+	if err != nil {
+		return err
+	}
+
+	err = k8s.ApplyResources(client, c.cli.LabelManager(), c.cli.Logger(), targetObjects)
+	if err != nil {
+		return err
+	}
+
+	err = k8s.WaitForResourcesConditions(config, k8s.NamesWithGVKFromK8sObjects(objectsToWaitFor(c.cli.Logger(), targetValues, targetObjects)), c.cli.WaitOptions(), k8s.ExistsConditionCheck, k8s.ReadyConditionCheck)
+	if err != nil {
+		return err
+	}
+
+	live, err := k8s.ListObjectsWithLabelSelector(config, internalk8s.CLIVersionLabel)
+	if err != nil {
+		return err
+	}
+
+	orphans := orphanedObjects(live, currentKeys, objectKeys(targetObjects))
+	if len(orphans) > 0 {
+		orphans.Sort(helm.UninstallObjectOrder())
+		err = k8s.DeleteResources(client, c.cli.LabelManager(), c.cli.Logger(), orphans, k8s.WaitForResourceConditions(config, c.cli.WaitOptions(), k8s.NonExistsConditionCheck))
+		if err != nil {
+			return errors.WrapIf(err, "could not prune k8s resources")
+		}
+	}
+
+	return store.Save(&release.Record{
+		Name:       options.releaseName,
+		CLIVersion: c.cli.GetRootCommand().Version,
+		RollbackOf: target.Revision,
+		Values:     target.Values,
+		Options:    target.Options,
+		Objects:    objectKeys(targetObjects),
+	})
+}
+
+func getRecordedObjects(record *release.Record) (Values, object.K8sObjects, error) {
+	var values Values
+
+	err := json.Unmarshal(record.Values, &values)
+	if err != nil {
+		return values, nil, errors.WrapIfWithDetails(err, "could not unmarshal recorded values", "revision", record.Revision)
+	}
+
+	var options releaseOptions
+	if len(record.Options) > 0 {
+		err = json.Unmarshal(record.Options, &options)
+		if err != nil {
+			return values, nil, errors.WrapIfWithDetails(err, "could not unmarshal recorded options", "revision", record.Revision)
+		}
+		options.apply(&values)
+	}
+
+	objects, err := renderBackyardsObjects(values)
+	if err != nil {
+		return values, nil, err
+	}
+
+	// the registry and pull secret given on the command line take precedence over the recorded ones
+	registry := viper.GetString("images.registry")
+	if registry == "" {
+		registry = options.ImageRegistry
+	}
+	pullSecret := viper.GetString("images.pullSecret")
+	if pullSecret == "" {
+		pullSecret = options.ImagePullSecret
+	}
+
+	return values, images.Rewrite(objects, registry, pullSecret), nil
+}
+
+// objectKeys returns the keys identifying the given objects
+func objectKeys(objects object.K8sObjects) []string {
+	keys := make([]string, len(objects))
+	for i, obj := range objects {
+		keys[i] = obj.Hash()
+	}
+
+	return keys
+}
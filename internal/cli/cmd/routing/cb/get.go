@@ -16,12 +16,12 @@ package cb
 
 import (
 	"emperror.dev/errors"
-	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/routing/common"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
 	clierrors "github.com/banzaicloud/backyards-cli/internal/errors"
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 )
@@ -63,14 +63,14 @@ func newGetCommand(cli cli.CLI) *cobra.Command {
 				return err
 			}
 
-			return c.run(cli, options)
+			return util.RunForContexts(cli, c.runWithCLI(options))
 		},
 	}
 
 	flags := cmd.Flags()
 	flags.StringVar(&options.serviceID, "service", "", "Service name")
 
-	return cmd
+	return util.SupportMultiContext(cmd)
 }
 
 func getCircuitBreakerRulesByServiceName(cli cli.CLI, serviceName types.NamespacedName) (*CircuitBreakerSettings, error) {
@@ -118,13 +118,19 @@ func getCircuitBreakerRulesByServiceName(cli cli.CLI, serviceName types.Namespac
 	}, nil
 }
 
+func (c *getCommand) runWithCLI(options *getOptions) func(cli.CLI) error {
+	return func(cli cli.CLI) error {
+		return c.run(cli, options)
+	}
+}
+
 func (c *getCommand) run(cli cli.CLI, options *getOptions) error {
 	var err error
 
 	data, err := getCircuitBreakerRulesByServiceName(cli, options.serviceName)
 	if err != nil {
 		if clierrors.IsNotFound(err) {
-			log.Infof("no circuit breaker rules set for %s", options.serviceName)
+			cli.Logger().Infof("no circuit breaker rules set for %s", options.serviceName)
 			return nil
 		}
 		return err
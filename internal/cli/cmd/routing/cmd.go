@@ -32,6 +32,7 @@ func NewRootCmd(cli cli.CLI) *cobra.Command {
 	cmd.AddCommand(
 		ts.NewRootCmd(cli),
 		cb.NewRootCmd(cli),
+		newListCommand(cli),
 	)
 
 	return cmd
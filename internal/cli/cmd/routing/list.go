@@ -0,0 +1,166 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis/istio/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+)
+
+type listCommand struct{}
+
+type listOptions struct {
+	namespaces []string
+}
+
+// ServiceRouting is the routing configuration set for a service
+type ServiceRouting struct {
+	Namespace       string `json:"namespace"`
+	Name            string `json:"name"`
+	TrafficShifting string `json:"trafficShifting,omitempty"`
+	CircuitBreaker  bool   `json:"circuitBreaker"`
+}
+
+func newListCommand(cli cli.CLI) *cobra.Command {
+	c := &listCommand{}
+	options := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:           "list",
+		Aliases:       []string{"ls"},
+		Short:         "List the services with routing configurations",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			return util.RunForContexts(cli, c.runWithCLI(options))
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&options.namespaces, "namespaces", options.namespaces, "Namespaces to list the routing configurations of (defaults to every namespace)")
+
+	return util.SupportMultiContext(cmd)
+}
+
+func (c *listCommand) runWithCLI(options *listOptions) func(cli.CLI) error {
+	return func(cli cli.CLI) error {
+		return c.run(cli, options)
+	}
+}
+
+func (c *listCommand) run(cli cli.CLI, options *listOptions) error {
+	cl, err := cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	namespaces := options.namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	rules := make(map[types.NamespacedName]*ServiceRouting)
+	get := func(namespace, name string) *ServiceRouting {
+		key := types.NamespacedName{Namespace: namespace, Name: name}
+		if rules[key] == nil {
+			rules[key] = &ServiceRouting{
+				Namespace: namespace,
+				Name:      name,
+			}
+		}
+		return rules[key]
+	}
+
+	for _, namespace := range namespaces {
+		var vservices v1alpha3.VirtualServiceList
+		err = cl.List(context.Background(), &vservices, client.InNamespace(namespace))
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not list virtual services", "namespace", namespace)
+		}
+		for _, vservice := range vservices.Items {
+			if weights := subsetWeights(vservice); weights != "" {
+				get(vservice.Namespace, vservice.Name).TrafficShifting = weights
+			}
+		}
+
+		var drules v1alpha3.DestinationRuleList
+		err = cl.List(context.Background(), &drules, client.InNamespace(namespace))
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not list destination rules", "namespace", namespace)
+		}
+		for _, drule := range drules.Items {
+			if drule.Spec.TrafficPolicy != nil && drule.Spec.TrafficPolicy.ConnectionPool != nil {
+				get(drule.Namespace, drule.Name).CircuitBreaker = true
+			}
+		}
+	}
+
+	data := make([]ServiceRouting, 0, len(rules))
+	for _, r := range rules {
+		data = append(data, *r)
+	}
+	sort.Slice(data, func(i, j int) bool {
+		if data[i].Namespace != data[j].Namespace {
+			return data[i].Namespace < data[j].Namespace
+		}
+		return data[i].Name < data[j].Name
+	})
+
+	ctx := &output.Context{
+		Out:     cli.Out(),
+		Color:   cli.Color(),
+		Format:  cli.OutputFormat(),
+		Fields:  []string{"Namespace", "Name", "TrafficShifting", "CircuitBreaker"},
+		Headers: []string{"Namespace", "Service", "Traffic shifting", "Circuit breaker"},
+	}
+
+	err = output.Output(ctx, data)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
+
+// subsetWeights returns the weights of the subsets of the catch-all routes, the way 'routing ts get' shows them
+func subsetWeights(vservice v1alpha3.VirtualService) string {
+	parts := make([]string, 0)
+	for _, route := range vservice.Spec.HTTP {
+		if len(route.Match) > 0 {
+			continue
+		}
+		for _, r := range route.Route {
+			if r.Destination.Subset != "" {
+				parts = append(parts, fmt.Sprintf("%s=%d", r.Destination.Subset, r.Weight))
+			}
+		}
+	}
+	sort.Strings(parts)
+
+	return strings.Join(parts, ", ")
+}
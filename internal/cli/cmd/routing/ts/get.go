@@ -16,12 +16,12 @@ package ts
 
 import (
 	"emperror.dev/errors"
-	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/routing/common"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 )
 
@@ -62,14 +62,20 @@ func newGetCommand(cli cli.CLI) *cobra.Command {
 				return err
 			}
 
-			return c.run(cli, options)
+			return util.RunForContexts(cli, c.runWithCLI(options))
 		},
 	}
 
 	flags := cmd.Flags()
 	flags.StringVar(&options.serviceID, "service", "", "Service name")
 
-	return cmd
+	return util.SupportMultiContext(cmd)
+}
+
+func (c *getCommand) runWithCLI(options *getOptions) func(cli.CLI) error {
+	return func(cli cli.CLI) error {
+		return c.run(cli, options)
+	}
 }
 
 func (c *getCommand) run(cli cli.CLI, options *getOptions) error {
@@ -86,7 +92,7 @@ func (c *getCommand) run(cli cli.CLI, options *getOptions) error {
 	vservice, err := common.GetVirtualserviceByName(cli, options.serviceName)
 	if err != nil {
 		if k8serrors.IsNotFound(errors.Cause(err)) {
-			log.Infof("no traffic shifting rules set for %s", options.serviceName)
+			cli.Logger().Infof("no traffic shifting rules set for %s", options.serviceName)
 			return nil
 		}
 		return errors.WrapIf(err, "could not get service")
@@ -103,7 +109,7 @@ func (c *getCommand) run(cli cli.CLI, options *getOptions) error {
 		}
 	}
 
-	log.Infof("traffic shifting for %s is currently set to %s", options.serviceName, subsets)
+	cli.Logger().Infof("traffic shifting for %s is currently set to %s", options.serviceName, subsets)
 
 	return nil
 }
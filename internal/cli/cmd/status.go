@@ -0,0 +1,517 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/canary"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/certmanager"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/k8s"
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+	"github.com/banzaicloud/backyards-cli/pkg/output"
+	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+const (
+	statusNotInstalled = "not installed"
+	recentEventsWindow = time.Hour
+	maxReportedEvents  = 3
+)
+
+type statusCommand struct {
+	cli cli.CLI
+
+	events map[string][]corev1.Event
+}
+
+type StatusOptions struct {
+	canaryNamespace string
+	releaseName     string
+}
+
+// ComponentStatus describes the health of a managed component
+type ComponentStatus struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace"`
+	Healthy   bool     `json:"healthy"`
+	Ready     string   `json:"ready"`
+	Version   string   `json:"version,omitempty"`
+	Images    []string `json:"images,omitempty"`
+	Volumes   []string `json:"volumes,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+// ImageList is used by the table output
+func (s ComponentStatus) ImageList() string {
+	return strings.Join(s.Images, ", ")
+}
+
+// VolumeList is used by the table output
+func (s ComponentStatus) VolumeList() string {
+	return strings.Join(s.Volumes, ", ")
+}
+
+// LastWarning is used by the table output
+func (s ComponentStatus) LastWarning() string {
+	if len(s.Warnings) == 0 {
+		return ""
+	}
+	return s.Warnings[len(s.Warnings)-1]
+}
+
+type componentSelector struct {
+	name      string
+	namespace string
+	labels    map[string]string
+	names     []string
+}
+
+func NewStatusCommand(cli cli.CLI) *cobra.Command {
+	c := &statusCommand{
+		cli: cli,
+	}
+	options := &StatusOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "status [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Show the health of the components managed by Backyards",
+		Long: `Show the health of the components managed by Backyards.
+
+For each component it reports the ready replicas, version labels, image tags,
+the binding state of the persistent volume claims and the recent warning events
+of the related Kubernetes resources.`,
+		Example: `  # Show component health.
+  backyards status
+
+  # Show component health in JSON format.
+  backyards status -o json
+
+  # Show component health of several clusters.
+  backyards status --contexts staging,prod-eu,prod-us`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return util.RunForContexts(c.cli, c.runWithCLI(options))
+		},
+	}
+
+	cmd.Flags().StringVar(&options.releaseName, "release-name", defaultReleaseName, "Name of the release")
+	cmd.Flags().StringVar(&options.canaryNamespace, "canary-namespace", canary.DefaultNamespace, "Namespace of the canary operator")
+
+	return util.SupportMultiContext(cmd)
+}
+
+// runWithCLI returns a function running the command with the given CLI
+func (c *statusCommand) runWithCLI(options *StatusOptions) func(cli.CLI) error {
+	return func(cli cli.CLI) error {
+		c := &statusCommand{
+			cli: cli,
+		}
+
+		return c.run(options)
+	}
+}
+
+func (c *statusCommand) run(options *StatusOptions) error {
+	cl, err := c.cli.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	c.events = make(map[string][]corev1.Event)
+
+	statuses := make([]ComponentStatus, 0)
+
+	istioStatus, istioComponents, err := c.getIstioStatus(cl, istio.IstioNamespace)
+	if err != nil {
+		return err
+	}
+	statuses = append(statuses, istioStatus)
+
+	// cert-manager may be an existing installation in another namespace
+	certManagerNamespace := certmanager.CertManagerNamespace
+	installation, err := certmanager.DetectInstallation(cl)
+	if err != nil {
+		return err
+	}
+	if installation != nil {
+		certManagerNamespace = installation.Namespace
+	}
+
+	for _, selector := range c.getComponentSelectors(options, istioComponents, certManagerNamespace) {
+		status, err := c.getComponentStatus(cl, selector)
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return c.output(statuses)
+}
+
+func (c *statusCommand) getComponentSelectors(options *StatusOptions, istioComponents []string, certManagerNamespace string) []componentSelector {
+	backyardsNamespace := viper.GetString("backyards.namespace")
+	backyardsComponent := func(name, component string) componentSelector {
+		return componentSelector{
+			name:      name,
+			namespace: backyardsNamespace,
+			labels: map[string]string{
+				"app.kubernetes.io/instance":  options.releaseName,
+				"app.kubernetes.io/component": component,
+			},
+		}
+	}
+
+	selectors := []componentSelector{
+		{
+			name:      "istio-operator",
+			namespace: istio.IstioNamespace,
+			labels: map[string]string{
+				"app.kubernetes.io/name":      "istio-operator",
+				"app.kubernetes.io/component": "operator",
+			},
+		},
+	}
+
+	for _, name := range istioComponents {
+		selectors = append(selectors, componentSelector{
+			name:      name,
+			namespace: istio.IstioNamespace,
+			names:     []string{name},
+		})
+	}
+
+	selectors = append(selectors,
+		componentSelector{
+			name:      "cert-manager",
+			namespace: certManagerNamespace,
+			labels: map[string]string{
+				"app.kubernetes.io/instance": "cert-manager",
+			},
+		},
+		componentSelector{
+			name:      "canary-operator",
+			namespace: options.canaryNamespace,
+			labels: map[string]string{
+				"app.kubernetes.io/name":      "canary-operator",
+				"app.kubernetes.io/component": "operator",
+			},
+		},
+		backyardsComponent("backyards", "application"),
+		backyardsComponent("prometheus", "prometheus"),
+		backyardsComponent("grafana", "grafana"),
+		backyardsComponent("jaeger", "tracing"),
+		backyardsComponent("ingressgateway", "ingressgateway"),
+		backyardsComponent("auditsink", "auditsink"),
+	)
+
+	return selectors
+}
+
+func (c *statusCommand) getIstioStatus(cl k8sclient.Client, istioNamespace string) (ComponentStatus, []string, error) {
+	status := ComponentStatus{
+		Name:      "istio",
+		Namespace: istioNamespace,
+		Ready:     statusNotInstalled,
+	}
+
+	var istioCR v1beta1.Istio
+	err := cl.Get(context.Background(), types.NamespacedName{
+		Name:      istio.IstioCRName,
+		Namespace: istioNamespace,
+	}, &istioCR)
+	if err != nil {
+		if k8serrors.IsNotFound(err) || k8smeta.IsNoMatchError(err) {
+			return status, nil, nil
+		}
+		return status, nil, errors.WrapIf(err, "could not get Istio CR")
+	}
+
+	status.Ready = string(istioCR.Status.Status)
+	status.Healthy = istioCR.Status.Status == v1beta1.Available
+	status.Version = string(istioCR.Spec.Version)
+	if istioCR.Status.ErrorMessage != "" {
+		status.Warnings = append(status.Warnings, istioCR.Status.ErrorMessage)
+	}
+
+	return status, istio.ControlPlaneDeploymentNames(&istioCR), nil
+}
+
+func (c *statusCommand) getComponentStatus(cl k8sclient.Client, selector componentSelector) (ComponentStatus, error) {
+	status := ComponentStatus{
+		Name:      selector.name,
+		Namespace: selector.namespace,
+		Ready:     statusNotInstalled,
+	}
+
+	workloads, err := c.getWorkloads(cl, selector)
+	if err != nil {
+		return status, err
+	}
+	if len(workloads) == 0 {
+		return status, nil
+	}
+
+	var ready, desired int32
+	images := make(map[string]bool)
+	versions := make(map[string]bool)
+	for _, w := range workloads {
+		ready += w.ready
+		desired += w.desired
+		for _, container := range w.podSpec.Containers {
+			images[container.Image] = true
+		}
+		if v := w.labels["app.kubernetes.io/version"]; v != "" {
+			versions[v] = true
+		} else if v := w.labels["version"]; v != "" {
+			versions[v] = true
+		}
+
+		warnings, err := c.getRecentWarnings(cl, selector.namespace, w)
+		if err != nil {
+			return status, err
+		}
+		status.Warnings = append(status.Warnings, warnings...)
+	}
+
+	volumes, bound, err := c.getVolumeClaims(cl, selector.namespace, workloads)
+	if err != nil {
+		return status, err
+	}
+
+	status.Ready = fmt.Sprintf("%d/%d", ready, desired)
+	status.Healthy = ready == desired && bound
+	status.Volumes = volumes
+	status.Images = sortedKeys(images)
+	status.Version = strings.Join(sortedKeys(versions), ", ")
+
+	return status, nil
+}
+
+type workload struct {
+	kind     string
+	name     string
+	labels   map[string]string
+	selector map[string]string
+	ready    int32
+	desired  int32
+	podSpec  corev1.PodSpec
+}
+
+func (c *statusCommand) getWorkloads(cl k8sclient.Client, selector componentSelector) ([]workload, error) {
+	workloads := make([]workload, 0)
+
+	if len(selector.names) > 0 {
+		for _, name := range selector.names {
+			var deployment appsv1.Deployment
+			err := cl.Get(context.Background(), types.NamespacedName{
+				Name:      name,
+				Namespace: selector.namespace,
+			}, &deployment)
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "could not get deployment", "name", name)
+			}
+			workloads = append(workloads, deploymentWorkload(deployment))
+		}
+		return workloads, nil
+	}
+
+	var deployments appsv1.DeploymentList
+	err := cl.List(context.Background(), &deployments, client.InNamespace(selector.namespace), client.MatchingLabels(selector.labels))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not list deployments", "component", selector.name)
+	}
+	for _, deployment := range deployments.Items {
+		workloads = append(workloads, deploymentWorkload(deployment))
+	}
+
+	var statefulsets appsv1.StatefulSetList
+	err = cl.List(context.Background(), &statefulsets, client.InNamespace(selector.namespace), client.MatchingLabels(selector.labels))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not list statefulsets", "component", selector.name)
+	}
+	for _, statefulset := range statefulsets.Items {
+		desired := int32(1)
+		if statefulset.Spec.Replicas != nil {
+			desired = *statefulset.Spec.Replicas
+		}
+		workloads = append(workloads, workload{
+			kind:     "StatefulSet",
+			name:     statefulset.Name,
+			labels:   statefulset.Labels,
+			selector: matchLabels(statefulset.Spec.Selector),
+			ready:    statefulset.Status.ReadyReplicas,
+			desired:  desired,
+			podSpec:  statefulset.Spec.Template.Spec,
+		})
+	}
+
+	return workloads, nil
+}
+
+func deploymentWorkload(deployment appsv1.Deployment) workload {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	return workload{
+		kind:     "Deployment",
+		name:     deployment.Name,
+		labels:   deployment.Labels,
+		selector: matchLabels(deployment.Spec.Selector),
+		ready:    deployment.Status.ReadyReplicas,
+		desired:  desired,
+		podSpec:  deployment.Spec.Template.Spec,
+	}
+}
+
+func matchLabels(selector *metav1.LabelSelector) map[string]string {
+	if selector == nil {
+		return nil
+	}
+
+	return selector.MatchLabels
+}
+
+// getVolumeClaims returns the binding state of the persistent volume claims mounted by the workloads
+// and whether all of them are bound
+func (c *statusCommand) getVolumeClaims(cl k8sclient.Client, namespace string, workloads []workload) ([]string, bool, error) {
+	volumes := make([]string, 0)
+	bound := true
+
+	for _, w := range workloads {
+		for _, volume := range w.podSpec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+
+			var pvc corev1.PersistentVolumeClaim
+			err := cl.Get(context.Background(), types.NamespacedName{
+				Name:      volume.PersistentVolumeClaim.ClaimName,
+				Namespace: namespace,
+			}, &pvc)
+			if k8serrors.IsNotFound(err) {
+				volumes = append(volumes, fmt.Sprintf("%s: missing", volume.PersistentVolumeClaim.ClaimName))
+				bound = false
+				continue
+			}
+			if err != nil {
+				return nil, false, errors.WrapIfWithDetails(err, "could not get persistent volume claim", "name", volume.PersistentVolumeClaim.ClaimName)
+			}
+
+			state := string(pvc.Status.Phase)
+			if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+				state += " " + capacity.String()
+			}
+			volumes = append(volumes, fmt.Sprintf("%s: %s", pvc.Name, state))
+			if pvc.Status.Phase != corev1.ClaimBound {
+				bound = false
+			}
+		}
+	}
+
+	return volumes, bound, nil
+}
+
+// getRecentWarnings returns the recent warning events of the workload and its pods
+func (c *statusCommand) getRecentWarnings(cl k8sclient.Client, namespace string, w workload) ([]string, error) {
+	events, ok := c.events[namespace]
+	if !ok {
+		var eventList corev1.EventList
+		err := cl.List(context.Background(), &eventList, client.InNamespace(namespace), client.MatchingField("type", corev1.EventTypeWarning))
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not list events", "namespace", namespace)
+		}
+		events = eventList.Items
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].LastTimestamp.Before(&events[j].LastTimestamp)
+		})
+		c.events[namespace] = events
+	}
+
+	objects, err := k8s.NewEventObjects(cl, w.kind, namespace, w.name, w.selector)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := make([]string, 0)
+	for _, event := range events {
+		if time.Since(event.LastTimestamp.Time) > recentEventsWindow {
+			continue
+		}
+		if !objects.Involves(event) {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("%s: %s", event.Reason, strings.TrimSpace(event.Message)))
+	}
+
+	if len(warnings) > maxReportedEvents {
+		warnings = warnings[len(warnings)-maxReportedEvents:]
+	}
+
+	return warnings, nil
+}
+
+func (c *statusCommand) output(statuses []ComponentStatus) error {
+	ctx := &output.Context{
+		Out:     c.cli.Out(),
+		Color:   c.cli.Color(),
+		Format:  c.cli.OutputFormat(),
+		Fields:  []string{"Name", "Namespace", "Ready", "Version", "ImageList", "VolumeList", "LastWarning"},
+		Headers: []string{"Component", "Namespace", "Ready", "Version", "Images", "Volumes", "Last warning"},
+	}
+
+	err := output.Output(ctx, statuses)
+	if err != nil {
+		return errors.WrapIf(err, "could not produce output")
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
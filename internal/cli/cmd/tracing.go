@@ -0,0 +1,88 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	defaultTracingStorageSize = "5Gi"
+)
+
+// tracingStorage holds the storage settings of the bundled Jaeger
+type tracingStorage struct {
+	persist      bool
+	storageSize  string
+	storageClass string
+	retention    time.Duration
+}
+
+func (s *tracingStorage) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.BoolVar(&s.persist, "tracing-persistence", s.persist, "Store the traces of the bundled Jaeger on a persistent volume")
+	flags.StringVar(&s.storageSize, "tracing-storage-size", defaultTracingStorageSize, "Size of the persistent volume of the traces")
+	flags.StringVar(&s.storageClass, "tracing-storage-class", s.storageClass, "Storage class of the persistent volume of the traces (defaults to the default storage class)")
+	flags.DurationVar(&s.retention, "tracing-retention", s.retention, "Time to keep the traces for on the persistent volume (defaults to 72h)")
+}
+
+// validate checks the consistency of the tracing storage flags
+func (s tracingStorage) validate(endpoints externalEndpoints) error {
+	if endpoints.tracingAddress != "" && (s.persist || s.retention != 0) {
+		return errors.New("the storage of an external tracing backend cannot be configured")
+	}
+
+	if !s.persist && (s.storageClass != "" || s.storageSize != defaultTracingStorageSize) {
+		return errors.New("the storage size and class of the traces require the --tracing-persistence flag")
+	}
+
+	// the traces kept in memory are limited by their number instead
+	if !s.persist && s.retention != 0 {
+		return errors.New("the retention of the traces requires the --tracing-persistence flag")
+	}
+
+	_, err := resource.ParseQuantity(s.storageSize)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "invalid tracing storage size", "size", s.storageSize)
+	}
+
+	if s.retention < 0 {
+		return errors.NewWithDetails("tracing retention must not be negative", "retention", s.retention)
+	}
+
+	return nil
+}
+
+// setValues sets the storage of the bundled Jaeger
+func (s tracingStorage) setValues(values *Values) {
+	if !s.persist {
+		return
+	}
+
+	if s.retention > 0 {
+		values.Tracing.Jaeger.Retention = s.retention.String()
+	}
+	values.Tracing.Jaeger.Persist = true
+	values.Tracing.Jaeger.SpanStorageType = "badger"
+	values.Tracing.Jaeger.StorageClassName = s.storageClass
+	values.Tracing.Jaeger.StorageSize = s.storageSize
+	// a single Jaeger instance writes the volume
+	values.Tracing.Jaeger.AccessMode = string(corev1.ReadWriteOnce)
+}
@@ -16,12 +16,10 @@ package cmd
 
 import (
 	"fmt"
-	"time"
 
 	"emperror.dev/errors"
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/canary"
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/certmanager"
@@ -35,15 +33,16 @@ import (
 type uninstallCommand struct{}
 
 type UninstallOptions struct {
-	releaseName    string
-	istioNamespace string
-	dumpResources  bool
+	releaseName   string
+	dumpResources bool
 
 	uninstallCanary      bool
 	uninstallDemoapp     bool
 	uninstallIstio       bool
+	forceIstio           bool
 	uninstallCertManager bool
 	uninstallEverything  bool
+	demoNamespace        string
 }
 
 func NewUninstallCommand(cli cli.CLI) *cobra.Command {
@@ -89,12 +88,13 @@ It can only dump the removable resources with the '--dump-resources' option.`,
 	}
 
 	cmd.Flags().StringVar(&options.releaseName, "release-name", "backyards", "Name of the release")
-	cmd.Flags().StringVar(&options.istioNamespace, "istio-namespace", "istio-system", "Namespace of Istio sidecar injector")
 	cmd.Flags().BoolVarP(&options.dumpResources, "dump-resources", "d", false, "Dump resources to stdout instead of applying them")
 
 	cmd.Flags().BoolVar(&options.uninstallCanary, "uninstall-canary", false, "Uninstall Canary feature as well")
 	cmd.Flags().BoolVar(&options.uninstallDemoapp, "uninstall-demoapp", false, "Uninstall Demo application as well")
+	cmd.Flags().StringVar(&options.demoNamespace, "demo-namespace", demoapp.GetNamespace(), "Namespace for demo application")
 	cmd.Flags().BoolVar(&options.uninstallIstio, "uninstall-istio", false, "Uninstall Istio mesh as well")
+	cmd.Flags().BoolVar(&options.forceIstio, "force-istio", false, "Uninstall Istio even if workloads still use it")
 	cmd.Flags().BoolVar(&options.uninstallCertManager, "uninstall-cert-manager", false, "Uninstall cert-manager as well")
 	cmd.Flags().BoolVarP(&options.uninstallEverything, "uninstall-everything", "a", false, "Uninstall every component at once")
 
@@ -102,7 +102,7 @@ It can only dump the removable resources with the '--dump-resources' option.`,
 }
 
 func (c *uninstallCommand) run(cli cli.CLI, options *UninstallOptions) error {
-	values, err := getValues(options.releaseName, options.istioNamespace, nil)
+	values, err := getValues(options.releaseName, istio.IstioNamespace, nil)
 	if err != nil {
 		return err
 	}
@@ -120,16 +120,17 @@ func (c *uninstallCommand) run(cli cli.CLI, options *UninstallOptions) error {
 			return err
 		}
 
-		err = k8s.DeleteResources(client, cli.LabelManager(), objects, k8s.WaitForResourceConditions(wait.Backoff{
-			Duration: time.Second * 5,
-			Factor:   1,
-			Jitter:   0,
-			Steps:    24,
-		}, k8s.NonExistsConditionCheck))
+		config, err := cli.GetK8sConfig()
+		if err != nil {
+			return err
+		}
+
+		err = k8s.DeleteResources(client, cli.LabelManager(), cli.Logger(), objects, k8s.WaitForResourceConditions(config, cli.WaitOptions(), k8s.NonExistsConditionCheck))
 		if err != nil {
 			return errors.WrapIf(err, "could not delete k8s resources")
 		}
-		return nil
+
+		return removeIngressEndpoint(cli)
 	}
 
 	yaml, err := objects.YAMLManifest()
@@ -147,6 +148,7 @@ func (c *uninstallCommand) runSubcommands(cli cli.CLI, options *UninstallOptions
 
 	if options.uninstallDemoapp || options.uninstallEverything {
 		scmdOptions := demoapp.NewUninstallOptions()
+		scmdOptions.Namespace = options.demoNamespace
 		if options.dumpResources {
 			scmdOptions.DumpResources = true
 		}
@@ -183,6 +185,7 @@ func (c *uninstallCommand) runSubcommands(cli cli.CLI, options *UninstallOptions
 
 	if options.uninstallIstio || options.uninstallEverything {
 		scmdOptions := istio.NewUninstallOptions()
+		scmdOptions.Force = options.forceIstio
 		if options.dumpResources {
 			scmdOptions.DumpResources = true
 		}
@@ -0,0 +1,119 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+)
+
+// Contexts returns the kubeconfig contexts selected with the --contexts or --all-contexts flags
+func Contexts() ([]string, error) {
+	if viper.GetBool("allKubecontexts") {
+		contexts, err := k8sclient.GetContexts(viper.GetString("kubeconfig"))
+		if err != nil {
+			return nil, errors.WrapIf(err, "could not load kubeconfig contexts")
+		}
+		return contexts, nil
+	}
+
+	return viper.GetStringSlice("kubecontexts"), nil
+}
+
+const multiContextAnnotation = "backyards.banzaicloud.io/multi-context"
+
+// SupportMultiContext marks the command as one which can run against several kubeconfig contexts
+func SupportMultiContext(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[multiContextAnnotation] = "true"
+
+	return cmd
+}
+
+// CheckMultiContext returns an error if several kubeconfig contexts were selected for a command
+// which does not support them, instead of running it against the current context only
+func CheckMultiContext(cmd *cobra.Command) error {
+	if !IsMultiContext() || cmd.Annotations[multiContextAnnotation] == "true" {
+		return nil
+	}
+
+	return errors.Errorf("the --contexts and --all-contexts flags are not supported by '%s'", cmd.CommandPath())
+}
+
+// IsMultiContext reports whether the command should run against several kubeconfig contexts
+func IsMultiContext() bool {
+	return viper.GetBool("allKubecontexts") || len(viper.GetStringSlice("kubecontexts")) > 0
+}
+
+// RunForContexts runs fn concurrently against every selected kubeconfig context, or once with the
+// given CLI if no contexts were selected. The output of each run is written grouped by context, while
+// the log messages of each run are prefixed with the name of its context.
+func RunForContexts(c cli.CLI, fn func(cli cli.CLI) error) error {
+	if !IsMultiContext() {
+		return fn(c)
+	}
+
+	contexts, err := Contexts()
+	if err != nil {
+		return err
+	}
+	if len(contexts) == 0 {
+		return errors.New("no kubeconfig contexts found")
+	}
+
+	outputs := make([]bytes.Buffer, len(contexts))
+	errs := make([]error, len(contexts))
+
+	var wg sync.WaitGroup
+	for i, kubeContext := range contexts {
+		wg.Add(1)
+		go func(i int, kubeContext string) {
+			defer wg.Done()
+
+			cl := c.ForContext(kubeContext, &outputs[i])
+			cl.Logger().Debug("running against the context")
+			errs[i] = fn(cl)
+		}(i, kubeContext)
+	}
+	wg.Wait()
+
+	var combinedErr error
+	for i, kubeContext := range contexts {
+		fmt.Fprintf(c.Out(), "# context: %s\n", kubeContext)
+		_, _ = outputs[i].WriteTo(c.Out())
+		if i < len(contexts)-1 {
+			fmt.Fprintln(c.Out())
+		}
+		if errs[i] != nil {
+			combinedErr = errors.Combine(combinedErr, errors.WrapIff(errs[i], "context %s", kubeContext))
+		}
+	}
+
+	if combinedErr != nil {
+		return errors.WrapIf(combinedErr, "command failed on some of the contexts")
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+)
+
+// PodsRunning checks whether pods with the given labels exist in the namespace and at least one of them is running
+func PodsRunning(cl k8sclient.Client, namespace string, podLabels map[string]string) (exists bool, healthy bool, err error) {
+	var pods corev1.PodList
+	err = cl.List(context.Background(), &pods, client.InNamespace(namespace), client.MatchingLabels(podLabels))
+	if err != nil {
+		err = errors.WrapIfWithDetails(err, "could not list pods", "namespace", namespace)
+		return
+	}
+	if len(pods.Items) > 0 {
+		exists = true
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning {
+			healthy = true
+			break
+		}
+	}
+	return
+}
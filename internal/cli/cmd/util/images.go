@@ -0,0 +1,27 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/spf13/viper"
+	"istio.io/operator/pkg/object"
+
+	"github.com/banzaicloud/backyards-cli/pkg/images"
+)
+
+// RewriteImages applies the image registry and pull secret overrides given on the command line to the objects
+func RewriteImages(objects object.K8sObjects) object.K8sObjects {
+	return images.Rewrite(objects, viper.GetString("images.registry"), viper.GetString("images.pullSecret"))
+}
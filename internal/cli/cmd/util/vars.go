@@ -18,4 +18,7 @@ var (
 	SidecarPodLabels = map[string]string{
 		"istio": "sidecar-injector",
 	}
+	CertManagerPodLabels = map[string]string{
+		"app": "cert-manager",
+	}
 )
@@ -20,6 +20,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
 	"github.com/banzaicloud/backyards-cli/internal/platform/buildinfo"
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
 )
@@ -52,23 +53,36 @@ func NewVersionCommand(cli cli.CLI) *cobra.Command {
 		Short:         "Print the client and api version information",
 		Args:          cobra.NoArgs,
 		SilenceErrors: true,
-		Run: func(cmd *cobra.Command, args []string) {
-			c.run(cli, options)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.onlyClientVersion {
+				c.run(cli, options)
+				return nil
+			}
+
+			return util.RunForContexts(cli, c.runWithCLI(options))
 		},
 	}
 
 	cmd.PersistentFlags().BoolVar(&options.shortVersion, "short", options.shortVersion, "Print the version number(s) only, with no additional output")
 	cmd.PersistentFlags().BoolVar(&options.onlyClientVersion, "client", options.onlyClientVersion, "Print the client version only")
 
-	return cmd
+	return util.SupportMultiContext(cmd)
+}
+
+// runWithCLI returns a function running the command with the given CLI
+func (c *versionCommand) runWithCLI(options *versionOptions) func(cli.CLI) error {
+	return func(cli cli.CLI) error {
+		c.run(cli, options)
+		return nil
+	}
 }
 
 func (c *versionCommand) run(cli cli.CLI, options *versionOptions) {
 	clientVersion := cli.GetRootCommand().Version
 	if options.shortVersion {
-		fmt.Println(clientVersion)
+		fmt.Fprintln(cli.Out(), clientVersion)
 	} else {
-		fmt.Printf("Client version: %s\n", clientVersion)
+		fmt.Fprintf(cli.Out(), "Client version: %s\n", clientVersion)
 	}
 
 	if options.onlyClientVersion {
@@ -77,9 +91,9 @@ func (c *versionCommand) run(cli cli.CLI, options *versionOptions) {
 
 	apiVersion := getAPIVersion(cli, versionEndpoint)
 	if options.shortVersion {
-		fmt.Println(apiVersion)
+		fmt.Fprintln(cli.Out(), apiVersion)
 	} else {
-		fmt.Printf("API version: %s\n", apiVersion)
+		fmt.Fprintf(cli.Out(), "API version: %s\n", apiVersion)
 	}
 }
 
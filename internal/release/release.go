@@ -0,0 +1,171 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clierrors "github.com/banzaicloud/backyards-cli/internal/errors"
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+)
+
+const (
+	NameLabel     = "backyards.banzaicloud.io/release-name"
+	RevisionLabel = "backyards.banzaicloud.io/release-revision"
+
+	secretType = "backyards.banzaicloud.io/release.v1"
+	secretKey  = "release"
+)
+
+// Record describes a single applied revision of a release
+type Record struct {
+	Name       string          `json:"name"`
+	Namespace  string          `json:"namespace"`
+	Revision   int             `json:"revision"`
+	CLIVersion string          `json:"cliVersion"`
+	Timestamp  time.Time       `json:"timestamp"`
+	RollbackOf int             `json:"rollbackOf,omitempty"`
+	Values     json.RawMessage `json:"values"`
+	Options    json.RawMessage `json:"options,omitempty"`
+	// Objects are the keys of the objects applied by the revision
+	Objects []string `json:"objects,omitempty"`
+}
+
+// Store persists release records as secrets in the namespace of the release
+type Store struct {
+	client    k8sclient.Client
+	namespace string
+}
+
+func NewStore(client k8sclient.Client, namespace string) *Store {
+	return &Store{
+		client:    client,
+		namespace: namespace,
+	}
+}
+
+// List returns every stored record of the named release ordered by revision
+func (s *Store) List(name string) ([]*Record, error) {
+	var secrets corev1.SecretList
+	err := s.client.List(context.Background(), &secrets, client.InNamespace(s.namespace), client.MatchingLabels(map[string]string{
+		NameLabel: name,
+	}))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not list release records", "name", name)
+	}
+
+	records := make([]*Record, 0, len(secrets.Items))
+	for _, secret := range secrets.Items {
+		if secret.Type != secretType {
+			continue
+		}
+		var record Record
+		err = json.Unmarshal(secret.Data[secretKey], &record)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not unmarshal release record", "secret", secret.Name)
+		}
+		records = append(records, &record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Revision < records[j].Revision
+	})
+
+	return records, nil
+}
+
+// Get returns the given revision of the named release
+func (s *Store) Get(name string, revision int) (*Record, error) {
+	records, err := s.List(name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if record.Revision == revision {
+			return record, nil
+		}
+	}
+
+	return nil, clierrors.NotFoundError{}
+}
+
+// Latest returns the most recent revision of the named release
+func (s *Store) Latest(name string) (*Record, error) {
+	records, err := s.List(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, clierrors.NotFoundError{}
+	}
+
+	return records[len(records)-1], nil
+}
+
+// Save stores the record as the next revision of its release
+func (s *Store) Save(record *Record) error {
+	latest, err := s.Latest(record.Name)
+	if err != nil && !clierrors.IsNotFound(err) {
+		return err
+	}
+
+	record.Revision = 1
+	if latest != nil {
+		record.Revision = latest.Revision + 1
+	}
+	record.Namespace = s.namespace
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now().UTC()
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.WrapIf(err, "could not marshal release record")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.release.v%d", record.Name, record.Revision),
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				NameLabel:     record.Name,
+				RevisionLabel: strconv.Itoa(record.Revision),
+			},
+		},
+		Type: secretType,
+		Data: map[string][]byte{
+			secretKey: data,
+		},
+	}
+
+	err = s.client.Create(context.Background(), secret)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not save release record", "name", record.Name, "revision", record.Revision)
+	}
+
+	return nil
+}
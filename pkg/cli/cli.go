@@ -58,7 +58,11 @@ type CLI interface {
 	GetRootCommand() *cobra.Command
 	GetK8sClient() (k8sclient.Client, error)
 	GetK8sConfig() (*rest.Config, error)
+	CurrentContext() (string, error)
+	GetPortforwardForPod(podLabels map[string]string, namespace string, localPort, remotePort int) (*portforward.Portforward, error)
 	LabelManager() k8s.LabelManager
+	WaitOptions() k8s.WaitOptions
+	Logger() logrus.FieldLogger
 
 	// An endpoint can be currently:
 	// - external HTTP(s) endpoint
@@ -67,6 +71,9 @@ type CLI interface {
 	InitializedEndpoint() (endpoint.Endpoint, error)
 	PersistentEndpoint() (endpoint.Endpoint, error)
 
+	// ForContext returns a non-interactive CLI which targets the given kubeconfig context and writes to out
+	ForContext(kubeContext string, out io.Writer) CLI
+
 	Stop() error
 }
 
@@ -75,6 +82,12 @@ type backyardsCLI struct {
 	rootCmd      *cobra.Command
 	labelManager k8s.LabelManager
 	lmOnce       sync.Once
+	waitOptions  k8s.WaitOptions
+	woOnce       sync.Once
+
+	kubeContext    string
+	nonInteractive bool
+	logger         logrus.FieldLogger
 }
 
 func NewCli(out io.Writer, rootCmd *cobra.Command) CLI {
@@ -85,6 +98,17 @@ func NewCli(out io.Writer, rootCmd *cobra.Command) CLI {
 	}
 }
 
+func (c *backyardsCLI) ForContext(kubeContext string, out io.Writer) CLI {
+	return &backyardsCLI{
+		out:            out,
+		lmOnce:         sync.Once{},
+		rootCmd:        c.rootCmd,
+		kubeContext:    kubeContext,
+		nonInteractive: true,
+		logger:         newContextLogger(kubeContext),
+	}
+}
+
 func (c *backyardsCLI) GetRootCommand() *cobra.Command {
 	return c.rootCmd
 }
@@ -94,6 +118,10 @@ func (c *backyardsCLI) InteractiveTerminal() bool {
 }
 
 func (c *backyardsCLI) Interactive() bool {
+	if c.nonInteractive {
+		return false
+	}
+
 	if isatty.IsTerminal(os.Stdout.Fd()) && isatty.IsTerminal(os.Stdin.Fd()) {
 		return !viper.GetBool("formatting.non-interactive")
 	}
@@ -132,7 +160,7 @@ func (c *backyardsCLI) GetPortforwardForPod(podLabels map[string]string, namespa
 		return nil, err
 	}
 
-	logrus.Debugf("Creating port forward: local port %d namespace: %s pod labels: %s remote port: %d",
+	c.Logger().Debugf("Creating port forward: local port %d namespace: %s pod labels: %s remote port: %d",
 		localPort, namespace, podLabels, remotePort)
 	pf, err := portforward.New(client, config, podLabels, namespace, localPort, remotePort)
 	if err != nil {
@@ -143,9 +171,9 @@ func (c *backyardsCLI) GetPortforwardForPod(podLabels map[string]string, namespa
 }
 
 func (c *backyardsCLI) GetK8sClient() (k8sclient.Client, error) {
-	config, err := k8sclient.GetConfigWithContext(viper.GetString("kubeconfig"), viper.GetString("kubecontext"))
+	config, err := c.GetK8sConfig()
 	if err != nil {
-		return nil, errors.WrapIf(err, "could not get k8s config")
+		return nil, err
 	}
 
 	client, err := k8sclient.NewClient(config, k8sclient.Options{})
@@ -156,8 +184,26 @@ func (c *backyardsCLI) GetK8sClient() (k8sclient.Client, error) {
 	return client, nil
 }
 
+// CurrentContext returns the name of the kubeconfig context the CLI targets
+func (c *backyardsCLI) CurrentContext() (string, error) {
+	kubeContext, err := k8sclient.GetCurrentContext(viper.GetString("kubeconfig"), c.contextOverride())
+	if err != nil {
+		return "", errors.WrapIf(err, "could not get current kubeconfig context")
+	}
+
+	return kubeContext, nil
+}
+
+func (c *backyardsCLI) contextOverride() string {
+	if c.kubeContext != "" {
+		return c.kubeContext
+	}
+
+	return viper.GetString("kubecontext")
+}
+
 func (c *backyardsCLI) GetK8sConfig() (*rest.Config, error) {
-	config, err := k8sclient.GetConfigWithContext(viper.GetString("kubeconfig"), viper.GetString("kubecontext"))
+	config, err := k8sclient.GetConfigWithContext(viper.GetString("kubeconfig"), c.contextOverride())
 	if err != nil {
 		return nil, errors.WrapIf(err, "could not get k8s config")
 	}
@@ -172,6 +218,29 @@ func (c *backyardsCLI) LabelManager() k8s.LabelManager {
 	return c.labelManager
 }
 
+// WaitOptions returns the same options for every wait of the command, so that they share the deadline of the timeout
+func (c *backyardsCLI) WaitOptions() k8s.WaitOptions {
+	c.woOnce.Do(func() {
+		timeout := viper.GetDuration("wait.timeout")
+		if timeout <= 0 {
+			timeout = k8s.DefaultWaitTimeout
+		}
+
+		c.waitOptions = k8s.NewWaitOptions(timeout, !c.nonInteractive && isatty.IsTerminal(os.Stderr.Fd()) && !viper.GetBool("formatting.non-interactive"), os.Stderr, c.Logger())
+	})
+	return c.waitOptions
+}
+
+// Logger returns the logger of the CLI, which prefixes the messages with the kubeconfig context when it runs
+// against one of several contexts
+func (c *backyardsCLI) Logger() logrus.FieldLogger {
+	if c.logger != nil {
+		return c.logger
+	}
+
+	return logrus.StandardLogger()
+}
+
 func (c *backyardsCLI) InitializedEndpoint() (endpoint.Endpoint, error) {
 	return c.endpoint(0)
 }
@@ -196,7 +265,19 @@ func withHealthCheck(ep endpoint.Endpoint) (endpoint.Endpoint, error) {
 
 func (c *backyardsCLI) endpoint(persistentPort int) (endpoint.Endpoint, error) {
 	url := viper.GetString("backyards.url")
-	ca, err := getEndpointCA()
+	caFile := viper.GetString("backyards.cacert")
+	if url == "" {
+		saved, err := c.savedEndpoint()
+		if err != nil {
+			return nil, err
+		}
+		url = saved.URL
+		if caFile == "" {
+			caFile = saved.CACert
+		}
+	}
+
+	ca, err := getEndpointCA(caFile)
 	if err != nil {
 		return nil, err
 	}
@@ -238,9 +319,24 @@ func (c *backyardsCLI) endpoint(persistentPort int) (endpoint.Endpoint, error) {
 	return withHealthCheck(endpoint.NewExternalEndpoint(url, ca))
 }
 
-func getEndpointCA() ([]byte, error) {
-	if viper.GetString("backyards.cacert") != "" {
-		return ioutil.ReadFile(viper.GetString("backyards.cacert"))
+// savedEndpoint returns the endpoint saved for the Backyards installation of the current context, if there is one
+func (c *backyardsCLI) savedEndpoint() (SavedEndpoint, error) {
+	kubeContext, err := c.CurrentContext()
+	if err != nil {
+		return SavedEndpoint{}, err
+	}
+
+	saved, _, err := GetSavedEndpoint(kubeContext, viper.GetString("backyards.namespace"))
+	if err != nil {
+		return SavedEndpoint{}, err
+	}
+
+	return saved, nil
+}
+
+func getEndpointCA(caFile string) ([]byte, error) {
+	if caFile != "" {
+		return ioutil.ReadFile(caFile)
 	}
 	return nil, nil
 }
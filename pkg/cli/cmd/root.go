@@ -32,9 +32,13 @@ import (
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/certmanager"
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/demoapp"
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/graph"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/images"
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/istio"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/load"
 	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/routing"
+	"github.com/banzaicloud/backyards-cli/internal/cli/cmd/util"
 	"github.com/banzaicloud/backyards-cli/pkg/cli"
+	"github.com/banzaicloud/backyards-cli/pkg/k8s"
 )
 
 const (
@@ -81,8 +85,29 @@ var RootCmd = &cobra.Command{
 			backyardsNamespace = namespaceFromEnv
 		}
 
-		if !namespaceRegex.MatchString(backyardsNamespace) {
-			return errors.NewWithDetails("invalid namespace", "namespace", backyardsNamespace)
+		istioNamespaceFromEnv := os.Getenv("ISTIO_NAMESPACE")
+		if istio.IstioNamespace == istio.DefaultNamespace && istioNamespaceFromEnv != "" {
+			istio.IstioNamespace = istioNamespaceFromEnv
+		}
+
+		certManagerNamespaceFromEnv := os.Getenv("CERT_MANAGER_NAMESPACE")
+		if certmanager.CertManagerNamespace == certmanager.DefaultNamespace && certManagerNamespaceFromEnv != "" {
+			certmanager.CertManagerNamespace = certManagerNamespaceFromEnv
+		}
+
+		if kubeContext != "" && util.IsMultiContext() {
+			return errors.New("the --context flag cannot be used together with --contexts or --all-contexts")
+		}
+
+		err := util.CheckMultiContext(cmd)
+		if err != nil {
+			return err
+		}
+
+		for _, namespace := range []string{backyardsNamespace, istio.IstioNamespace, certmanager.CertManagerNamespace} {
+			if !namespaceRegex.MatchString(namespace) {
+				return errors.NewWithDetails("invalid namespace", "namespace", namespace)
+			}
 		}
 
 		return nil
@@ -116,15 +141,40 @@ func Execute() {
 	}
 }
 
+// initConfig reads the CLI config file, the flags take precedence over its settings
+func initConfig() {
+	viper.SetConfigFile(cli.ConfigFile())
+	err := viper.ReadInConfig()
+	if err != nil && !os.IsNotExist(err) {
+		log.Warnf("could not read config file: %s", err)
+	}
+}
+
 func init() {
+	cobra.OnInitialize(initConfig)
+
 	flags := RootCmd.PersistentFlags()
+	flags.String("config", "", "path to the CLI config file (defaults to ~/.backyards/config.yaml)")
+	_ = viper.BindPFlag("config", flags.Lookup("config"))
 	flags.StringVarP(&backyardsNamespace, "namespace", "n", defaultNamespace, "namespace in which Backyards is installed [$BACKYARDS_NAMESPACE]")
 	_ = viper.BindPFlag("backyards.namespace", flags.Lookup("namespace"))
+	flags.StringVar(&istio.IstioNamespace, "istio-namespace", istio.DefaultNamespace, "namespace in which Istio is installed [$ISTIO_NAMESPACE]")
+	flags.StringVar(&certmanager.CertManagerNamespace, "cert-manager-namespace", certmanager.DefaultNamespace, "namespace in which cert-manager is installed [$CERT_MANAGER_NAMESPACE]")
 	flags.StringVarP(&kubeconfigPath, "kubeconfig", "c", "", "path to the kubeconfig file to use for CLI requests")
 	_ = viper.BindPFlag("kubeconfig", flags.Lookup("kubeconfig"))
 	flags.StringVar(&kubeContext, "context", "", "name of the kubeconfig context to use")
 	_ = viper.BindPFlag("kubecontext", flags.Lookup("context"))
+	flags.StringSlice("contexts", nil, "names of the kubeconfig contexts to run the command against concurrently (install, status, version, routing list and the routing get commands only)")
+	_ = viper.BindPFlag("kubecontexts", flags.Lookup("contexts"))
+	flags.Bool("all-contexts", false, "run the command against every kubeconfig context concurrently (install, status, version, routing list and the routing get commands only)")
+	_ = viper.BindPFlag("allKubecontexts", flags.Lookup("all-contexts"))
 	flags.BoolVarP(&verbose, "verbose", "v", false, "turn on debug logging")
+	flags.Duration("timeout", k8s.DefaultWaitTimeout, "maximum time to wait for resources to become ready during the whole command")
+	_ = viper.BindPFlag("wait.timeout", flags.Lookup("timeout"))
+	flags.String("image-registry", "", "registry to pull every deployed image from instead of the public ones")
+	_ = viper.BindPFlag("images.registry", flags.Lookup("image-registry"))
+	flags.String("image-pull-secret", "", "name of the secret used to pull the deployed images, it must exist in every target namespace")
+	_ = viper.BindPFlag("images.pullSecret", flags.Lookup("image-pull-secret"))
 
 	flags.StringVarP(&outputFormat, "output", "o", "table", "output format (table|yaml|json)")
 	_ = viper.BindPFlag("output.format", flags.Lookup("output"))
@@ -149,12 +199,21 @@ func init() {
 	RootCmd.AddCommand(cmd.NewVersionCommand(cli))
 	RootCmd.AddCommand(cmd.NewInstallCommand(cli))
 	RootCmd.AddCommand(cmd.NewUninstallCommand(cli))
+	RootCmd.AddCommand(cmd.NewRollbackCommand(cli))
+	RootCmd.AddCommand(cmd.NewStatusCommand(cli))
+	RootCmd.AddCommand(cmd.NewPreflightCommand(cli, cmd.NewPreflightOptions()))
+	RootCmd.AddCommand(cmd.NewPruneCommand(cli))
+	RootCmd.AddCommand(cmd.NewBackupCommand(cli))
+	RootCmd.AddCommand(cmd.NewRestoreCommand(cli))
+	RootCmd.AddCommand(cmd.NewProfileCommand(cli))
 	RootCmd.AddCommand(cmd.NewDashboardCommand(cli, cmd.NewDashboardOptions()))
 	RootCmd.AddCommand(istio.NewRootCmd(cli))
 	RootCmd.AddCommand(canary.NewRootCmd(cli))
 	RootCmd.AddCommand(demoapp.NewRootCmd(cli))
+	RootCmd.AddCommand(load.NewLoadCommand(cli, load.NewLoadOptions()))
 	RootCmd.AddCommand(routing.NewRootCmd(cli))
 	RootCmd.AddCommand(certmanager.NewRootCmd(cli))
+	RootCmd.AddCommand(images.NewRootCmd(cli))
 	RootCmd.AddCommand(graph.NewGraphCmd(cli, "base.json"))
 	RootCmd.AddCommand(login.NewLoginCmd(cli))
 
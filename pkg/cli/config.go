@@ -0,0 +1,183 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigFile returns the path of the CLI config file
+func ConfigFile() string {
+	if file := viper.GetString("config"); file != "" {
+		return file
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".backyards", "config.yaml")
+	}
+
+	return filepath.Join(home, ".backyards", "config.yaml")
+}
+
+// SavedEndpoint is the Backyards endpoint saved for an installation in a kubeconfig context
+type SavedEndpoint struct {
+	Context   string `json:"context"`
+	Namespace string `json:"namespace"`
+	URL       string `json:"url"`
+	CACert    string `json:"cacert,omitempty"`
+}
+
+const endpointsKey = "endpoints"
+
+// configMu serializes the writes of the config file by concurrent runs against multiple contexts
+var configMu sync.Mutex
+
+// SaveEndpoint saves the endpoint of an installation, replacing the one saved earlier for it
+func SaveEndpoint(endpoint SavedEndpoint) error {
+	return updateEndpoints(func(endpoints []SavedEndpoint) []SavedEndpoint {
+		return append(withoutEndpoint(endpoints, endpoint.Context, endpoint.Namespace), endpoint)
+	})
+}
+
+// RemoveEndpoint removes the saved endpoint of an installation, it returns whether there was one
+func RemoveEndpoint(context, namespace string) (bool, error) {
+	removed := false
+	err := updateEndpoints(func(endpoints []SavedEndpoint) []SavedEndpoint {
+		remaining := withoutEndpoint(endpoints, context, namespace)
+		removed = len(remaining) < len(endpoints)
+		return remaining
+	})
+
+	return removed, err
+}
+
+// GetSavedEndpoint returns the saved endpoint of an installation
+func GetSavedEndpoint(context, namespace string) (SavedEndpoint, bool, error) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	config, err := readConfig()
+	if err != nil {
+		return SavedEndpoint{}, false, err
+	}
+
+	endpoints, err := getEndpoints(config)
+	if err != nil {
+		return SavedEndpoint{}, false, err
+	}
+
+	for _, endpoint := range endpoints {
+		if endpoint.Context == context && endpoint.Namespace == namespace {
+			return endpoint, true, nil
+		}
+	}
+
+	return SavedEndpoint{}, false, nil
+}
+
+func updateEndpoints(update func([]SavedEndpoint) []SavedEndpoint) error {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	config, err := readConfig()
+	if err != nil {
+		return err
+	}
+
+	endpoints, err := getEndpoints(config)
+	if err != nil {
+		return err
+	}
+
+	config[endpointsKey] = update(endpoints)
+
+	return writeConfig(config)
+}
+
+func withoutEndpoint(endpoints []SavedEndpoint, context, namespace string) []SavedEndpoint {
+	remaining := make([]SavedEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if endpoint.Context != context || endpoint.Namespace != namespace {
+			remaining = append(remaining, endpoint)
+		}
+	}
+
+	return remaining
+}
+
+func getEndpoints(config map[string]interface{}) ([]SavedEndpoint, error) {
+	endpoints := make([]SavedEndpoint, 0)
+	if config[endpointsKey] == nil {
+		return endpoints, nil
+	}
+
+	content, err := yaml.Marshal(config[endpointsKey])
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not marshal saved endpoints")
+	}
+	err = yaml.Unmarshal(content, &endpoints)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not parse saved endpoints")
+	}
+
+	return endpoints, nil
+}
+
+func readConfig() (map[string]interface{}, error) {
+	file := ConfigFile()
+
+	config := make(map[string]interface{})
+	content, err := ioutil.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.WrapIfWithDetails(err, "could not read config file", "file", file)
+	}
+	if err == nil {
+		err = yaml.Unmarshal(content, &config)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not parse config file", "file", file)
+		}
+	}
+
+	return config, nil
+}
+
+func writeConfig(config map[string]interface{}) error {
+	file := ConfigFile()
+
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.WrapIf(err, "could not marshal config")
+	}
+
+	err = os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not create config directory", "file", file)
+	}
+
+	err = ioutil.WriteFile(file, content, 0600)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not write config file", "file", file)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// prefixFormatter prepends a prefix to the message of every log entry
+type prefixFormatter struct {
+	prefix    string
+	formatter logrus.Formatter
+}
+
+func (f *prefixFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	prefixed := *entry
+	prefixed.Message = f.prefix + entry.Message
+
+	return f.formatter.Format(&prefixed)
+}
+
+// newContextLogger returns a logger which writes like the standard logger with the name of the kubeconfig context
+// prepended to every message, so that the logs of the concurrent runs of a command can be told apart
+func newContextLogger(kubeContext string) logrus.FieldLogger {
+	std := logrus.StandardLogger()
+
+	logger := logrus.New()
+	logger.SetOutput(std.Out)
+	logger.SetLevel(std.GetLevel())
+	logger.SetFormatter(&prefixFormatter{
+		prefix:    "[" + kubeContext + "] ",
+		formatter: std.Formatter,
+	})
+
+	return logger
+}
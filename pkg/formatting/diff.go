@@ -0,0 +1,117 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatting
+
+import (
+	"strings"
+
+	"github.com/ttacon/chalk"
+)
+
+const diffContextLines = 3
+
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// Diff returns the line by line difference of two texts with a few lines of context around the changes
+func Diff(a, b string, color bool) string {
+	lines := diffLines(strings.Split(strings.TrimSuffix(a, "\n"), "\n"), strings.Split(strings.TrimSuffix(b, "\n"), "\n"))
+
+	// mark the lines to print, which are the changes and their context
+	show := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+		for j := i - diffContextLines; j <= i+diffContextLines; j++ {
+			if j >= 0 && j < len(lines) {
+				show[j] = true
+			}
+		}
+	}
+
+	var out strings.Builder
+	skipped := false
+	for i, line := range lines {
+		if !show[i] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			out.WriteString("...\n")
+			skipped = false
+		}
+
+		text := string(line.op) + " " + line.text
+		if color {
+			switch line.op {
+			case '-':
+				text = chalk.Red.Color(text)
+			case '+':
+				text = chalk.Green.Color(text)
+			}
+		}
+		out.WriteString(text + "\n")
+	}
+
+	return out.String()
+}
+
+// diffLines computes the difference of two line lists using their longest common subsequence
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{op: ' ', text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{op: '-', text: a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{op: '+', text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{op: '-', text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{op: '+', text: b[j]})
+	}
+
+	return lines
+}
@@ -0,0 +1,77 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatting
+
+import (
+	"testing"
+
+	"github.com/ttacon/chalk"
+)
+
+func TestDiff(t *testing.T) {
+	tests := map[string]struct {
+		a        string
+		b        string
+		color    bool
+		expected string
+	}{
+		"identical": {
+			a:        "a\nb\nc\n",
+			b:        "a\nb\nc\n",
+			expected: "",
+		},
+		"changed line": {
+			a:        "a\nb\nc\n",
+			b:        "a\nB\nc\n",
+			expected: "  a\n- b\n+ B\n  c\n",
+		},
+		"added line": {
+			a:        "a\nb",
+			b:        "a\nb\nc\n",
+			expected: "  a\n  b\n+ c\n",
+		},
+		"removed line": {
+			a:        "a\nb\nc",
+			b:        "a\nc",
+			expected: "  a\n- b\n  c\n",
+		},
+		"context": {
+			a:        "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			b:        "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n",
+			expected: "...\n  2\n  3\n  4\n- 5\n+ five\n  6\n  7\n  8\n",
+		},
+		"separate changes": {
+			a:        "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			b:        "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			expected: "- 1\n+ one\n  2\n  3\n  4\n...\n  7\n  8\n  9\n- 10\n+ ten\n",
+		},
+		"color": {
+			a:        "a\nb\n",
+			b:        "a\nc\n",
+			color:    true,
+			expected: "  a\n" + chalk.Red.Color("- b") + "\n" + chalk.Green.Color("+ c") + "\n",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			if got := Diff(test.a, test.b, test.color); got != test.expected {
+				t.Errorf("unexpected diff\ngot : %q\nwant: %q", got, test.expected)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ type GenerateLoadRequest struct {
 	Port      int
 	Endpoint  string
 	Method    string
+	Body      string
 	Frequency int
 	Duration  int
 	Headers   map[string]string
@@ -50,6 +51,9 @@ func (c *client) GenerateLoad(req GenerateLoadRequest) (GenerateLoadResponse, er
 	r.Var("frequency", req.Frequency)
 	r.Var("duration", req.Duration)
 	r.Var("headers", req.Headers)
+	if req.Body != "" {
+		r.Var("body", req.Body)
+	}
 
 	// run it and capture the response
 	var respData map[string]GenerateLoadResponse
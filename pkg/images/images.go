@@ -0,0 +1,151 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"sort"
+	"strings"
+
+	"istio.io/operator/pkg/object"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// imageFields are the keys which hold image references in pod specs and custom resources
+var imageFields = map[string]bool{
+	"image":       true,
+	"pluginImage": true,
+}
+
+// podSpecPaths are the locations of pod specs in the workload kinds
+var podSpecPaths = map[string][]string{
+	"Pod":         {"spec"},
+	"Deployment":  {"spec", "template", "spec"},
+	"StatefulSet": {"spec", "template", "spec"},
+	"DaemonSet":   {"spec", "template", "spec"},
+	"ReplicaSet":  {"spec", "template", "spec"},
+	"Job":         {"spec", "template", "spec"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// RewriteReference returns the reference of the image in the given registry keeping its repository path and tag
+func RewriteReference(image, registry string) string {
+	if registry == "" {
+		return image
+	}
+
+	return strings.TrimSuffix(registry, "/") + "/" + repositoryPath(image)
+}
+
+// repositoryPath strips the registry host from the image reference
+func repositoryPath(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return parts[1]
+	}
+
+	return image
+}
+
+// Rewrite points every image reference of the objects to the registry and adds the pull secret to the pod specs
+func Rewrite(objects object.K8sObjects, registry, pullSecret string) object.K8sObjects {
+	if registry == "" && pullSecret == "" {
+		return objects
+	}
+
+	rewritten := make(object.K8sObjects, len(objects))
+	for i, obj := range objects {
+		u := obj.UnstructuredObject().DeepCopy()
+		if registry != "" {
+			u.Object = rewriteImages(u.Object, registry).(map[string]interface{})
+		}
+		if pullSecret != "" {
+			addPullSecret(u, pullSecret)
+		}
+		rewritten[i] = object.NewK8sObject(u, nil, nil)
+	}
+
+	return rewritten
+}
+
+// List returns the unique image references of the objects
+func List(objects object.K8sObjects) []string {
+	images := make(map[string]bool)
+	for _, obj := range objects {
+		collectImages(obj.UnstructuredObject().Object, images)
+	}
+
+	list := make([]string, 0, len(images))
+	for image := range images {
+		list = append(list, image)
+	}
+	sort.Strings(list)
+
+	return list
+}
+
+func rewriteImages(value interface{}, registry string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if image, ok := field.(string); ok && imageFields[key] && image != "" {
+				v[key] = RewriteReference(image, registry)
+				continue
+			}
+			v[key] = rewriteImages(field, registry)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = rewriteImages(item, registry)
+		}
+	}
+
+	return value
+}
+
+func collectImages(value interface{}, images map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if image, ok := field.(string); ok && imageFields[key] && image != "" {
+				images[image] = true
+				continue
+			}
+			collectImages(field, images)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectImages(item, images)
+		}
+	}
+}
+
+func addPullSecret(u *unstructured.Unstructured, pullSecret string) {
+	path, ok := podSpecPaths[u.GetKind()]
+	if !ok {
+		return
+	}
+
+	secrets, _, _ := unstructured.NestedSlice(u.Object, append(path, "imagePullSecrets")...)
+	for _, secret := range secrets {
+		if s, ok := secret.(map[string]interface{}); ok && s["name"] == pullSecret {
+			return
+		}
+	}
+	secrets = append(secrets, map[string]interface{}{
+		"name": pullSecret,
+	})
+
+	_ = unstructured.SetNestedSlice(u.Object, secrets, append(path, "imagePullSecrets")...)
+}
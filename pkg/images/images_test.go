@@ -0,0 +1,209 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/operator/pkg/object"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRewriteReference(t *testing.T) {
+	tests := map[string]struct {
+		image    string
+		registry string
+		expected string
+	}{
+		"no registry": {
+			image:    "banzaicloud/backyards:1.0.0",
+			registry: "",
+			expected: "banzaicloud/backyards:1.0.0",
+		},
+		"docker hub image": {
+			image:    "banzaicloud/backyards:1.0.0",
+			registry: "registry.example.com/mirror",
+			expected: "registry.example.com/mirror/banzaicloud/backyards:1.0.0",
+		},
+		"official image": {
+			image:    "busybox",
+			registry: "registry.example.com/",
+			expected: "registry.example.com/busybox",
+		},
+		"registry host": {
+			image:    "docker.io/jaegertracing/all-in-one:1.12",
+			registry: "registry.example.com",
+			expected: "registry.example.com/jaegertracing/all-in-one:1.12",
+		},
+		"registry host with port": {
+			image:    "registry.local:5000/istio/proxyv2:1.2.5",
+			registry: "registry.example.com",
+			expected: "registry.example.com/istio/proxyv2:1.2.5",
+		},
+		"localhost registry": {
+			image:    "localhost/backyards-web:dev",
+			registry: "registry.example.com",
+			expected: "registry.example.com/backyards-web:dev",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			if got := RewriteReference(test.image, test.registry); got != test.expected {
+				t.Errorf("unexpected image reference\ngot : %s\nwant: %s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	newObject := func(kind string, content map[string]interface{}) *object.K8sObject {
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion("v1")
+		u.SetKind(kind)
+		u.SetName("test")
+
+		return object.NewK8sObject(u, nil, nil)
+	}
+
+	tests := map[string]struct {
+		object     *object.K8sObject
+		registry   string
+		pullSecret string
+		expected   map[string]interface{}
+	}{
+		"pod spec images": {
+			object: newObject("Deployment", map[string]interface{}{
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"initContainers": []interface{}{
+								map[string]interface{}{"name": "init", "image": "busybox"},
+							},
+							"containers": []interface{}{
+								map[string]interface{}{"name": "app", "image": "quay.io/banzaicloud/app:1"},
+							},
+						},
+					},
+				},
+			}),
+			registry: "registry.example.com",
+			expected: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"initContainers": []interface{}{
+								map[string]interface{}{"name": "init", "image": "registry.example.com/busybox"},
+							},
+							"containers": []interface{}{
+								map[string]interface{}{"name": "app", "image": "registry.example.com/banzaicloud/app:1"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"custom resource images": {
+			object: newObject("Canary", map[string]interface{}{
+				"spec": map[string]interface{}{
+					"image":       "banzaicloud/canary:1",
+					"pluginImage": "banzaicloud/plugin:1",
+					"tag":         "1",
+				},
+			}),
+			registry: "registry.example.com",
+			expected: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"image":       "registry.example.com/banzaicloud/canary:1",
+					"pluginImage": "registry.example.com/banzaicloud/plugin:1",
+					"tag":         "1",
+				},
+			},
+		},
+		"empty image": {
+			object: newObject("Canary", map[string]interface{}{
+				"spec": map[string]interface{}{
+					"pluginImage": "",
+				},
+			}),
+			registry: "registry.example.com",
+			expected: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"pluginImage": "",
+				},
+			},
+		},
+		"pull secret": {
+			object: newObject("Pod", map[string]interface{}{
+				"spec": map[string]interface{}{
+					"imagePullSecrets": []interface{}{
+						map[string]interface{}{"name": "existing"},
+					},
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "busybox"},
+					},
+				},
+			}),
+			pullSecret: "mirror",
+			expected: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"imagePullSecrets": []interface{}{
+						map[string]interface{}{"name": "existing"},
+						map[string]interface{}{"name": "mirror"},
+					},
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "busybox"},
+					},
+				},
+			},
+		},
+		"pull secret of a kind without pod spec": {
+			object: newObject("ConfigMap", map[string]interface{}{
+				"data": map[string]interface{}{
+					"image": "busybox",
+				},
+			}),
+			pullSecret: "mirror",
+			expected: map[string]interface{}{
+				"data": map[string]interface{}{
+					"image": "busybox",
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			original := test.object.UnstructuredObject().DeepCopy()
+			rewritten := Rewrite(object.K8sObjects{test.object}, test.registry, test.pullSecret)
+
+			got := rewritten[0].UnstructuredObject().Object
+			for _, field := range []string{"apiVersion", "kind", "metadata"} {
+				delete(got, field)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("unexpected rewritten object\ngot : %v\nwant: %v", got, test.expected)
+			}
+			if !reflect.DeepEqual(test.object.UnstructuredObject(), original) {
+				t.Errorf("the original object is modified\ngot : %v\nwant: %v", test.object.UnstructuredObject(), original)
+			}
+		})
+	}
+}
@@ -14,6 +14,8 @@
 package client
 
 import (
+	"sort"
+
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -38,3 +40,41 @@ func GetConfigWithContext(kubeconfigPath, kubeContext string) (*rest.Config, err
 		NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).
 		ClientConfig()
 }
+
+// GetContexts returns the names of the contexts in the kubeconfig
+func GetContexts(kubeconfigPath string) ([]string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfigPath != "" {
+		rules.ExplicitPath = kubeconfigPath
+	}
+	config, err := rules.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+
+	return contexts, nil
+}
+
+// GetCurrentContext returns the name of the given context, or the current context of the kubeconfig if it is empty
+func GetCurrentContext(kubeconfigPath, kubeContext string) (string, error) {
+	if kubeContext != "" {
+		return kubeContext, nil
+	}
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfigPath != "" {
+		rules.ExplicitPath = kubeconfigPath
+	}
+	config, err := rules.Load()
+	if err != nil {
+		return "", err
+	}
+
+	return config.CurrentContext, nil
+}
@@ -0,0 +1,224 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+)
+
+const (
+	diagnosticsMaxEvents   = 5
+	diagnosticsMaxPods     = 3
+	diagnosticsLogTailSize = 10
+)
+
+// diagnoser collects the reasons why a resource did not become ready
+type diagnoser struct {
+	client    k8sclient.Client
+	clientset kubernetes.Interface
+}
+
+func newDiagnoser(config *rest.Config) (*diagnoser, error) {
+	cl, err := k8sclient.NewClient(config, k8sclient.Options{})
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create k8s client")
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create k8s clientset")
+	}
+
+	return &diagnoser{
+		client:    cl,
+		clientset: clientset,
+	}, nil
+}
+
+// diagnose returns human readable lines about the pods, recent warning events and failing container logs of the object
+func (d *diagnoser) diagnose(object *unstructured.Unstructured) []string {
+	lines := make([]string, 0)
+
+	actual := object.DeepCopy()
+	err := d.client.Get(context.Background(), client.ObjectKey{
+		Name:      object.GetName(),
+		Namespace: object.GetNamespace(),
+	}, actual)
+	if err != nil {
+		return append(lines, fmt.Sprintf("could not get resource: %s", err))
+	}
+
+	selector := podSelector(actual)
+	if selector != nil {
+		lines = append(lines, d.diagnosePods(actual.GetNamespace(), selector)...)
+	}
+
+	if actual.GetNamespace() != "" {
+		lines = append(lines, d.recentWarnings(actual, selector)...)
+	}
+
+	return lines
+}
+
+func (d *diagnoser) diagnosePods(namespace string, selector map[string]string) []string {
+	lines := make([]string, 0)
+
+	var pods corev1.PodList
+	err := d.client.List(context.Background(), &pods, client.InNamespace(namespace), client.MatchingLabels(selector))
+	if err != nil {
+		return append(lines, fmt.Sprintf("could not list pods: %s", err))
+	}
+
+	reported := 0
+	for _, pod := range pods.Items {
+		if reported >= diagnosticsMaxPods {
+			break
+		}
+
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		failing := false
+		for _, status := range statuses {
+			if status.Ready {
+				continue
+			}
+			reason := containerReason(status)
+			if reason == "" {
+				continue
+			}
+			failing = true
+			lines = append(lines, fmt.Sprintf("pod %s container %s: %s", pod.Name, status.Name, reason))
+			lines = append(lines, d.containerLogs(pod, status)...)
+		}
+
+		if !failing && pod.Status.Phase == corev1.PodPending {
+			for _, condition := range pod.Status.Conditions {
+				if condition.Status != corev1.ConditionTrue && condition.Message != "" {
+					failing = true
+					lines = append(lines, fmt.Sprintf("pod %s: %s: %s", pod.Name, condition.Reason, condition.Message))
+				}
+			}
+		}
+
+		if failing {
+			reported++
+		}
+	}
+
+	return lines
+}
+
+// containerLogs returns the last log lines of the container, of its previous run if it has been restarted
+func (d *diagnoser) containerLogs(pod corev1.Pod, status corev1.ContainerStatus) []string {
+	if status.State.Running == nil && status.State.Terminated == nil && status.RestartCount == 0 {
+		return nil
+	}
+
+	tail := int64(diagnosticsLogTailSize)
+	raw, err := d.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: status.Name,
+		TailLines: &tail,
+		Previous:  status.RestartCount > 0 && status.State.Running == nil,
+	}).Do().Raw()
+	if err != nil {
+		return []string{fmt.Sprintf("  could not get logs: %s", err)}
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(strings.TrimSpace(string(raw)), "\n") {
+		if line != "" {
+			lines = append(lines, "  | "+line)
+		}
+	}
+
+	return lines
+}
+
+// recentWarnings returns the last warning events of the object and of the pods matching the selector
+func (d *diagnoser) recentWarnings(object *unstructured.Unstructured, selector map[string]string) []string {
+	objects, err := NewEventObjects(d.client, object.GetKind(), object.GetNamespace(), object.GetName(), selector)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var events corev1.EventList
+	err = d.client.List(context.Background(), &events, client.InNamespace(object.GetNamespace()), client.MatchingField("type", corev1.EventTypeWarning))
+	if err != nil {
+		return []string{fmt.Sprintf("could not list events: %s", err)}
+	}
+
+	sort.Slice(events.Items, func(i, j int) bool {
+		return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
+	})
+
+	lines := make([]string, 0)
+	for _, event := range events.Items {
+		if !objects.Involves(event) {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("event %s/%s: %s: %s", strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name, event.Reason, strings.TrimSpace(event.Message)))
+	}
+
+	if len(lines) > diagnosticsMaxEvents {
+		lines = lines[len(lines)-diagnosticsMaxEvents:]
+	}
+
+	return lines
+}
+
+func containerReason(status corev1.ContainerStatus) string {
+	switch {
+	case status.State.Waiting != nil:
+		if status.State.Waiting.Message != "" {
+			return fmt.Sprintf("%s: %s", status.State.Waiting.Reason, status.State.Waiting.Message)
+		}
+		return status.State.Waiting.Reason
+	case status.State.Terminated != nil:
+		return fmt.Sprintf("terminated: %s (exit code %d)", status.State.Terminated.Reason, status.State.Terminated.ExitCode)
+	case status.State.Running != nil:
+		return fmt.Sprintf("running but not ready (%d restarts)", status.RestartCount)
+	}
+
+	return ""
+}
+
+// podSelector returns the labels of the pods managed by the object if it is a workload
+func podSelector(obj *unstructured.Unstructured) map[string]string {
+	switch obj.GetKind() {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		selector, found, err := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+		if err != nil || !found {
+			return nil
+		}
+		return selector
+	case "Job":
+		return map[string]string{
+			"job-name": obj.GetName(),
+		}
+	}
+
+	return nil
+}
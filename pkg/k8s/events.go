@@ -0,0 +1,76 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+)
+
+// EventObjects are the objects whose events are reported for an object, keyed by kind and name
+type EventObjects map[string]bool
+
+// NewEventObjects returns the object itself and, for workloads, their replica sets and the pods matching
+// the selector, as the events about failing pods are reported on these
+func NewEventObjects(cl k8sclient.Client, kind, namespace, name string, selector map[string]string) (EventObjects, error) {
+	objects := EventObjects{
+		eventObjectKey(kind, name): true,
+	}
+
+	if len(selector) == 0 {
+		return objects, nil
+	}
+
+	if kind == "Deployment" {
+		var replicasets appsv1.ReplicaSetList
+		err := cl.List(context.Background(), &replicasets, client.InNamespace(namespace), client.MatchingLabels(selector))
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not list replica sets", "namespace", namespace)
+		}
+		for _, replicaset := range replicasets.Items {
+			for _, owner := range replicaset.OwnerReferences {
+				if owner.Kind == kind && owner.Name == name {
+					objects[eventObjectKey("ReplicaSet", replicaset.Name)] = true
+				}
+			}
+		}
+	}
+
+	var pods corev1.PodList
+	err := cl.List(context.Background(), &pods, client.InNamespace(namespace), client.MatchingLabels(selector))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not list pods", "namespace", namespace)
+	}
+	for _, pod := range pods.Items {
+		objects[eventObjectKey("Pod", pod.Name)] = true
+	}
+
+	return objects, nil
+}
+
+// Involves reports whether the event is about one of the objects
+func (o EventObjects) Involves(event corev1.Event) bool {
+	return o[eventObjectKey(event.InvolvedObject.Kind, event.InvolvedObject.Name)]
+}
+
+func eventObjectKey(kind, name string) string {
+	return kind + "/" + name
+}
@@ -0,0 +1,116 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"emperror.dev/errors"
+	log "github.com/sirupsen/logrus"
+	"istio.io/operator/pkg/object"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// ListObjectsWithLabelSelector lists the objects of every listable and deletable kind in every namespace
+// which match the label selector. Resources which cannot be listed, like forbidden ones or ones served by
+// an unavailable aggregated API, are skipped with a warning.
+func ListObjectsWithLabelSelector(config *rest.Config, labelSelector string) (object.K8sObjects, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create discovery client")
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create dynamic client")
+	}
+
+	resourceLists, err := dc.ServerPreferredResources()
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, errors.WrapIf(err, "could not discover server resources")
+		}
+		log.Warn(err)
+	}
+
+	resources := discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list", "delete"}}, resourceLists)
+
+	objects := make(object.K8sObjects, 0)
+	seen := make(map[string]bool)
+	for _, resourceList := range resources {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not parse group version", "groupVersion", resourceList.GroupVersion)
+		}
+
+		for _, resource := range resourceList.APIResources {
+			list, err := client.Resource(gv.WithResource(resource.Name)).List(metav1.ListOptions{
+				LabelSelector: labelSelector,
+			})
+			if err != nil {
+				log.Warnf("skipping %s in %s: %s", resource.Name, resourceList.GroupVersion, err)
+				continue
+			}
+
+			for i := range list.Items {
+				obj := object.NewK8sObject(&list.Items[i], nil, nil)
+				// the same kind can be served by multiple groups
+				if seen[obj.Hash()] {
+					continue
+				}
+				seen[obj.Hash()] = true
+				objects = append(objects, obj)
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// ListObjectsOfResources lists the objects of the given resources in the given namespaces, or in every
+// namespace if none are given. Resources which are not served by the cluster are skipped.
+func ListObjectsOfResources(config *rest.Config, resources []schema.GroupVersionResource, namespaces []string) (object.K8sObjects, error) {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create dynamic client")
+	}
+
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	objects := make(object.K8sObjects, 0)
+	for _, resource := range resources {
+		for _, namespace := range namespaces {
+			list, err := client.Resource(resource).Namespace(namespace).List(metav1.ListOptions{})
+			if k8serrors.IsNotFound(err) {
+				log.Debugf("resource %s is not served by the cluster", resource)
+				break
+			}
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "could not list resources", "resource", resource.String(), "namespace", namespace)
+			}
+
+			for i := range list.Items {
+				objects = append(objects, object.NewK8sObject(&list.Items[i], nil, nil))
+			}
+		}
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	statePending = "pending"
+	stateOK      = "ok"
+	stateFailed  = "failed"
+
+	progressRefreshInterval = 250 * time.Millisecond
+)
+
+// progress reports the state of the waited resources either as a live multi-line view or as plain log lines
+type progress struct {
+	out         io.Writer
+	interactive bool
+	log         log.FieldLogger
+
+	mu      sync.Mutex
+	names   []string
+	states  map[string]string
+	started time.Time
+	lines   int
+
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newProgress(out io.Writer, interactive bool, logger log.FieldLogger, names []string) *progress {
+	states := make(map[string]string, len(names))
+	for _, name := range names {
+		states[name] = statePending
+	}
+
+	return &progress{
+		out:         out,
+		interactive: interactive && out != nil,
+		log:         logger,
+		names:       names,
+		states:      states,
+		done:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+}
+
+func (p *progress) start() {
+	p.started = time.Now()
+
+	if !p.interactive {
+		for _, name := range p.names {
+			p.log.Infof("%s - %s", name, statePending)
+		}
+		close(p.stopped)
+		return
+	}
+
+	go func() {
+		defer close(p.stopped)
+		ticker := time.NewTicker(progressRefreshInterval)
+		defer ticker.Stop()
+		for {
+			p.render()
+			select {
+			case <-p.done:
+				p.render()
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (p *progress) set(name, state string) {
+	p.mu.Lock()
+	p.states[name] = state
+	p.mu.Unlock()
+
+	if p.interactive {
+		return
+	}
+
+	if state == stateOK {
+		p.log.Infof("%s - %s", name, state)
+	} else {
+		p.log.Errorf("%s - %s", name, state)
+	}
+}
+
+func (p *progress) stop() {
+	close(p.done)
+	<-p.stopped
+}
+
+// render redraws the whole view in place
+func (p *progress) render() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.lines > 0 {
+		fmt.Fprintf(p.out, "\x1b[%dA", p.lines)
+	}
+
+	elapsed := time.Since(p.started).Truncate(time.Second)
+	for _, name := range p.names {
+		state := p.states[name]
+		var mark string
+		switch state {
+		case stateOK:
+			mark = "✓"
+		case stateFailed:
+			mark = "✗"
+		default:
+			mark = "…"
+			state = fmt.Sprintf("%s (%s)", state, elapsed)
+		}
+		fmt.Fprintf(p.out, "\x1b[2K%s %s - %s\n", mark, name, state)
+	}
+	p.lines = len(p.names)
+}
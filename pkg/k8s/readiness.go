@@ -0,0 +1,184 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
+)
+
+// ReadyConditionCheck checks the readiness of the kinds which have a notion of it, every other kind is considered ready
+func ReadyConditionCheck(obj *unstructured.Unstructured, k8serror error) bool {
+	if k8serror != nil {
+		return false
+	}
+
+	switch obj.GetKind() {
+	case "Deployment":
+		var deployment appsv1.Deployment
+		if !fromUnstructured(obj, &deployment) {
+			return true
+		}
+		return deployment.Status.ObservedGeneration >= deployment.Generation &&
+			deployment.Status.UpdatedReplicas == desiredReplicas(deployment.Spec.Replicas) &&
+			deployment.Status.ReadyReplicas == desiredReplicas(deployment.Spec.Replicas)
+	case "StatefulSet":
+		var statefulset appsv1.StatefulSet
+		if !fromUnstructured(obj, &statefulset) {
+			return true
+		}
+		return statefulset.Status.ObservedGeneration >= statefulset.Generation &&
+			statefulset.Status.ReadyReplicas == desiredReplicas(statefulset.Spec.Replicas)
+	case "DaemonSet":
+		var daemonset appsv1.DaemonSet
+		if !fromUnstructured(obj, &daemonset) {
+			return true
+		}
+		return daemonset.Status.ObservedGeneration >= daemonset.Generation &&
+			daemonset.Status.UpdatedNumberScheduled == daemonset.Status.DesiredNumberScheduled &&
+			daemonset.Status.NumberReady == daemonset.Status.DesiredNumberScheduled
+	case "Job":
+		var job batchv1.Job
+		if !fromUnstructured(obj, &job) {
+			return true
+		}
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobComplete && condition.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+		return job.Status.Succeeded >= desiredReplicas(job.Spec.Completions)
+	case "Service":
+		var service corev1.Service
+		if !fromUnstructured(obj, &service) {
+			return true
+		}
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			return true
+		}
+		return len(service.Status.LoadBalancer.Ingress) > 0
+	case "APIService":
+		return hasTrueCondition(obj, "Available")
+	case "Certificate":
+		return hasTrueCondition(obj, "Ready")
+	}
+
+	return true
+}
+
+// WebhookEndpointsConditionCheck checks whether the services of admission webhooks have ready endpoints
+func WebhookEndpointsConditionCheck(client k8sclient.Client) ResourceConditionCheck {
+	return func(obj *unstructured.Unstructured, k8serror error) bool {
+		if k8serror != nil {
+			return false
+		}
+
+		services := make([]*admissionregistrationv1beta1.ServiceReference, 0)
+		switch obj.GetKind() {
+		case "MutatingWebhookConfiguration":
+			var config admissionregistrationv1beta1.MutatingWebhookConfiguration
+			if !fromUnstructured(obj, &config) {
+				return true
+			}
+			for _, webhook := range config.Webhooks {
+				services = append(services, webhook.ClientConfig.Service)
+			}
+		case "ValidatingWebhookConfiguration":
+			var config admissionregistrationv1beta1.ValidatingWebhookConfiguration
+			if !fromUnstructured(obj, &config) {
+				return true
+			}
+			for _, webhook := range config.Webhooks {
+				services = append(services, webhook.ClientConfig.Service)
+			}
+		default:
+			return true
+		}
+
+		for _, service := range services {
+			if service == nil {
+				continue
+			}
+			var endpoints corev1.Endpoints
+			err := client.Get(context.Background(), types.NamespacedName{
+				Name:      service.Name,
+				Namespace: service.Namespace,
+			}, &endpoints)
+			if err != nil {
+				log.Debug(err)
+				return false
+			}
+			if !hasReadyAddress(endpoints) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+func hasReadyAddress(endpoints corev1.Endpoints) bool {
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasTrueCondition checks the status conditions of resources which are not part of the scheme
+func hasTrueCondition(obj *unstructured.Unstructured, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == conditionType && condition["status"] == string(corev1.ConditionTrue) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, into interface{}) bool {
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, into)
+	if err != nil {
+		log.Debug(err)
+		return false
+	}
+
+	return true
+}
@@ -46,7 +46,7 @@ type LabelManager interface {
 
 type PostResourceApplyFunc func(k8sclient.Client, Object) error
 
-func ApplyResources(client k8sclient.Client, labelManager LabelManager, objects object.K8sObjects, waitFuncs ...WaitForResourceConditionsFunc) error {
+func ApplyResources(client k8sclient.Client, labelManager LabelManager, logger log.FieldLogger, objects object.K8sObjects, waitFuncs ...WaitForResourceConditionsFunc) error {
 	var err error
 
 	for _, obj := range objects {
@@ -61,24 +61,24 @@ func ApplyResources(client k8sclient.Client, labelManager LabelManager, objects
 		}, actual); err == nil {
 			skip, err := labelManager.CheckLabelsBeforeUpdate(actual, desired)
 			if err != nil {
-				log.Errorf("%s failed to check labels: %s", objectName, err)
+				logger.Errorf("%s failed to check labels: %s", objectName, err)
 				continue
 			}
 			if skip {
-				log.Warnf("%s skipping resource", objectName)
+				logger.Warnf("%s skipping resource", objectName)
 				continue
 			}
 			desired.SetResourceVersion(actual.GetResourceVersion())
 			patchResult, err := patch.DefaultPatchMaker.Calculate(actual, desired)
 			if err != nil {
-				log.Error(err, "could not match objects", "object", actual.GetKind())
+				logger.Error(err, "could not match objects", "object", actual.GetKind())
 			} else if patchResult.IsEmpty() {
-				log.Infof("%s unchanged", GetFormattedName(actual))
+				logger.Infof("%s unchanged", GetFormattedName(actual))
 				continue
 			}
 
 			if err := patch.DefaultAnnotator.SetLastAppliedAnnotation(desired); err != nil {
-				log.Error(err, "failed to set last applied annotation", "desired", desired)
+				logger.Error(err, "failed to set last applied annotation", "desired", desired)
 			}
 
 			desired = prepareObjectBeforeUpdate(actual, desired)
@@ -87,32 +87,32 @@ func ApplyResources(client k8sclient.Client, labelManager LabelManager, objects
 			if err != nil {
 				return errors.WrapIfWithDetails(err, "could not update resource", "name", objectName)
 			}
-			log.Infof("%s configured", objectName)
+			logger.Infof("%s configured", objectName)
 		} else {
 			if err := patch.DefaultAnnotator.SetLastAppliedAnnotation(desired); err != nil {
-				log.Error(err, "failed to set last applied annotation", "desired", desired)
+				logger.Error(err, "failed to set last applied annotation", "desired", desired)
 			}
 			skip, err := labelManager.CheckLabelsBeforeCreate(desired)
 			if err != nil {
-				log.Errorf("%s failed to check labels: %s", objectName, err)
+				logger.Errorf("%s failed to check labels: %s", objectName, err)
 				continue
 			}
 			if skip {
-				log.Warnf("%s skipping resource", objectName)
+				logger.Warnf("%s skipping resource", objectName)
 				continue
 			}
 			err = client.Create(context.Background(), desired)
 			if err != nil {
 				return errors.WrapIfWithDetails(err, "could not create resource", "name", objectName)
 			}
-			log.Infof("%s created", objectName)
+			logger.Infof("%s created", objectName)
 		}
 
 		if len(waitFuncs) > 0 {
 			for _, fn := range waitFuncs {
 				err = fn(client, actual)
 				if err != nil {
-					log.Error(err)
+					logger.Error(err)
 					continue
 				}
 			}
@@ -124,7 +124,7 @@ func ApplyResources(client k8sclient.Client, labelManager LabelManager, objects
 
 type PostResourceDeleteFunc func(k8sclient.Client, Object) error
 
-func DeleteResources(client k8sclient.Client, labelManager LabelManager, objects object.K8sObjects, waitFuncs ...WaitForResourceConditionsFunc) error {
+func DeleteResources(client k8sclient.Client, labelManager LabelManager, logger log.FieldLogger, objects object.K8sObjects, waitFuncs ...WaitForResourceConditionsFunc) error {
 	var err error
 
 	for _, obj := range objects {
@@ -136,20 +136,20 @@ func DeleteResources(client k8sclient.Client, labelManager LabelManager, objects
 		}, actual); err == nil {
 			skip, err := labelManager.CheckLabelsBeforeDelete(actual)
 			if err != nil {
-				log.Errorf("%s failed to check labels: %s", objectName, err)
+				logger.Errorf("%s failed to check labels: %s", objectName, err)
 				continue
 			}
 			if skip {
-				log.Warnf("%s skipping resource", objectName)
+				logger.Warnf("%s skipping resource", objectName)
 				continue
 			}
 			err = client.Delete(context.Background(), obj.UnstructuredObject())
 			if k8serrors.IsNotFound(err) || k8smeta.IsNoMatchError(err) {
-				log.Error(errors.WrapIf(err, "could not delete"))
+				logger.Error(errors.WrapIf(err, "could not delete"))
 				continue
 			}
 			if err != nil {
-				log.Error(err)
+				logger.Error(err)
 			}
 
 			deletionTimedOut := false
@@ -158,24 +158,24 @@ func DeleteResources(client k8sclient.Client, labelManager LabelManager, objects
 					err = fn(client, actual)
 					if err != nil {
 						deletionTimedOut = true
-						log.Error(err)
+						logger.Error(err)
 						continue
 					}
 				}
 			}
 
 			if deletionTimedOut {
-				log.Errorf("%s deletion timed out", objectName)
+				logger.Errorf("%s deletion timed out", objectName)
 			} else {
-				log.Infof("%s deleted", objectName)
+				logger.Infof("%s deleted", objectName)
 			}
 
 		} else {
 			err = errors.WrapIf(err, "could not delete")
 			if k8serrors.IsNotFound(err) {
-				log.Warning(err)
+				logger.Warning(err)
 			} else {
-				log.Error(err)
+				logger.Error(err)
 			}
 		}
 	}
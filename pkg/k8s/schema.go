@@ -0,0 +1,223 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+)
+
+// SchemaForPath returns the schema of the field at the given path, or nil if the schema does not describe it
+func SchemaForPath(schema *apiextensionsv1beta1.JSONSchemaProps, path []string) *apiextensionsv1beta1.JSONSchemaProps {
+	for _, key := range path {
+		if schema == nil {
+			return nil
+		}
+
+		if property, ok := schema.Properties[key]; ok {
+			schema = &property
+			continue
+		}
+
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			schema = schema.AdditionalProperties.Schema
+			continue
+		}
+
+		return nil
+	}
+
+	return schema
+}
+
+// ValidateWithSchema validates a value decoded from JSON against a CRD validation schema
+//
+// It checks types, enums, patterns, bounds, required and unknown fields, which covers
+// what the CRDs of the operators installed by the CLI make use of.
+func ValidateWithSchema(value interface{}, schema *apiextensionsv1beta1.JSONSchemaProps) error {
+	if schema == nil {
+		return nil
+	}
+
+	return validateWithSchema(value, schema, "")
+}
+
+func validateWithSchema(value interface{}, schema *apiextensionsv1beta1.JSONSchemaProps, path string) error {
+	if value == nil {
+		if schema.Nullable {
+			return nil
+		}
+		return schemaError(path, "must not be null")
+	}
+
+	if schema.Type != "" && !hasSchemaType(value, schema.Type) {
+		return schemaError(path, fmt.Sprintf("must be of type %s", schema.Type))
+	}
+
+	var err error
+	if len(schema.Enum) > 0 && !inEnum(value, schema.Enum) {
+		allowed := make([]string, len(schema.Enum))
+		for i, e := range schema.Enum {
+			allowed[i] = string(e.Raw)
+		}
+		err = errors.Append(err, schemaError(path, fmt.Sprintf("must be one of %s", strings.Join(allowed, ", "))))
+	}
+
+	switch v := value.(type) {
+	case string:
+		if schema.Pattern != "" {
+			if re, rerr := regexp.Compile(schema.Pattern); rerr == nil && !re.MatchString(v) {
+				err = errors.Append(err, schemaError(path, fmt.Sprintf("must match %q", schema.Pattern)))
+			}
+		}
+	case float64, int64, int:
+		n := toFloat(v)
+		if schema.Minimum != nil && n < *schema.Minimum {
+			err = errors.Append(err, schemaError(path, fmt.Sprintf("must be at least %v", *schema.Minimum)))
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			err = errors.Append(err, schemaError(path, fmt.Sprintf("must be at most %v", *schema.Maximum)))
+		}
+	case []interface{}:
+		if schema.Items != nil && schema.Items.Schema != nil {
+			for i, item := range v {
+				err = errors.Append(err, validateWithSchema(item, schema.Items.Schema, fmt.Sprintf("%s[%d]", path, i)))
+			}
+		}
+	case map[string]interface{}:
+		for _, required := range schema.Required {
+			if _, ok := v[required]; !ok {
+				err = errors.Append(err, schemaError(joinSchemaPath(path, required), "is required"))
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if property, ok := schema.Properties[key]; ok {
+				err = errors.Append(err, validateWithSchema(v[key], &property, joinSchemaPath(path, key)))
+				continue
+			}
+
+			if schema.AdditionalProperties != nil {
+				if schema.AdditionalProperties.Schema != nil {
+					err = errors.Append(err, validateWithSchema(v[key], schema.AdditionalProperties.Schema, joinSchemaPath(path, key)))
+					continue
+				}
+				if schema.AdditionalProperties.Allows {
+					continue
+				}
+			}
+
+			// objects without listed properties are free-form
+			if len(schema.Properties) > 0 {
+				err = errors.Append(err, schemaError(joinSchemaPath(path, key), "is not a known field"))
+			}
+		}
+	}
+
+	return err
+}
+
+func hasSchemaType(value interface{}, schemaType string) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		switch value.(type) {
+		case float64, int64, int:
+			return true
+		}
+	case "integer":
+		switch v := value.(type) {
+		case int64, int:
+			return true
+		case float64:
+			return v == math.Trunc(v)
+		}
+	}
+
+	return false
+}
+
+func inEnum(value interface{}, enum []apiextensionsv1beta1.JSON) bool {
+	for _, e := range enum {
+		var allowed interface{}
+		if err := json.Unmarshal(e.Raw, &allowed); err != nil {
+			continue
+		}
+		if reflect.DeepEqual(normalizeNumber(value), normalizeNumber(allowed)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeNumber(value interface{}) interface{} {
+	switch value.(type) {
+	case int64, int:
+		return toFloat(value)
+	}
+	return value
+}
+
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	}
+	return 0
+}
+
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func schemaError(path, message string) error {
+	if path == "" {
+		path = "value"
+	}
+	return errors.Errorf("%s %s", path, message)
+}
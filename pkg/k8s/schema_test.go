@@ -0,0 +1,194 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+)
+
+const testSchema = `{
+  "type": "object",
+  "properties": {
+    "spec": {
+      "type": "object",
+      "required": ["version"],
+      "properties": {
+        "version": {"type": "string", "pattern": "^[0-9]+\\.[0-9]+"},
+        "mtls": {"type": "boolean"},
+        "replicas": {"type": "integer", "minimum": 1, "maximum": 10},
+        "ratio": {"type": "number"},
+        "policy": {"type": "string", "enum": ["ALLOW_ANY", "REGISTRY_ONLY"]},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+        "annotations": {"type": "object", "additionalProperties": true},
+        "values": {"type": "object"},
+        "nodeSelector": {"type": "object", "nullable": true},
+        "ports": {"type": "array", "items": {"type": "integer"}}
+      }
+    }
+  }
+}`
+
+func newTestSchema(t *testing.T) *apiextensionsv1beta1.JSONSchemaProps {
+	var schema apiextensionsv1beta1.JSONSchemaProps
+	err := json.Unmarshal([]byte(testSchema), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &schema
+}
+
+func TestValidateWithSchema(t *testing.T) {
+	tests := map[string]struct {
+		spec     map[string]interface{}
+		expected string
+	}{
+		"valid": {
+			spec: map[string]interface{}{
+				"version":      "1.4.2",
+				"mtls":         true,
+				"replicas":     int64(3),
+				"ratio":        0.5,
+				"policy":       "ALLOW_ANY",
+				"labels":       map[string]interface{}{"app": "web"},
+				"annotations":  map[string]interface{}{"count": int64(1)},
+				"values":       map[string]interface{}{"any": map[string]interface{}{"nested": true}},
+				"nodeSelector": nil,
+				"ports":        []interface{}{int64(80), float64(443)},
+			},
+		},
+		"type": {
+			spec:     map[string]interface{}{"version": "1.4", "mtls": "yes"},
+			expected: "spec.mtls must be of type boolean",
+		},
+		"fractional integer": {
+			spec:     map[string]interface{}{"version": "1.4", "replicas": 2.5},
+			expected: "spec.replicas must be of type integer",
+		},
+		"integer as number": {
+			spec: map[string]interface{}{"version": "1.4", "ratio": int64(1)},
+		},
+		"bounds": {
+			spec:     map[string]interface{}{"version": "1.4", "replicas": int64(11)},
+			expected: "spec.replicas must be at most 10",
+		},
+		"enum": {
+			spec:     map[string]interface{}{"version": "1.4", "policy": "DENY"},
+			expected: `spec.policy must be one of "ALLOW_ANY", "REGISTRY_ONLY"`,
+		},
+		"pattern": {
+			spec:     map[string]interface{}{"version": "latest"},
+			expected: `spec.version must match "^[0-9]+\\.[0-9]+"`,
+		},
+		"required": {
+			spec:     map[string]interface{}{"mtls": true},
+			expected: "spec.version is required",
+		},
+		"unknown field": {
+			spec:     map[string]interface{}{"version": "1.4", "mtsl": true},
+			expected: "spec.mtsl is not a known field",
+		},
+		"additional properties": {
+			spec:     map[string]interface{}{"version": "1.4", "labels": map[string]interface{}{"replicas": int64(1)}},
+			expected: "spec.labels.replicas must be of type string",
+		},
+		"null": {
+			spec:     map[string]interface{}{"version": "1.4", "mtls": nil},
+			expected: "spec.mtls must not be null",
+		},
+		"array items": {
+			spec:     map[string]interface{}{"version": "1.4", "ports": []interface{}{int64(80), "443"}},
+			expected: "spec.ports[1] must be of type integer",
+		},
+		"several errors": {
+			spec:     map[string]interface{}{"mtls": "yes"},
+			expected: "spec.version is required; spec.mtls must be of type boolean",
+		},
+	}
+
+	schema := newTestSchema(t)
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			err := ValidateWithSchema(map[string]interface{}{"spec": test.spec}, schema)
+
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != test.expected {
+				t.Errorf("unexpected validation result\ngot : %q\nwant: %q", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestSchemaForPath(t *testing.T) {
+	tests := map[string]struct {
+		path     []string
+		found    bool
+		expected string
+	}{
+		"root": {
+			path:     nil,
+			found:    true,
+			expected: "object",
+		},
+		"property": {
+			path:     []string{"spec", "replicas"},
+			found:    true,
+			expected: "integer",
+		},
+		"additional properties schema": {
+			path:     []string{"spec", "labels", "app"},
+			found:    true,
+			expected: "string",
+		},
+		"additional properties allowed": {
+			path: []string{"spec", "annotations", "count"},
+		},
+		"free-form object": {
+			path: []string{"spec", "values", "any"},
+		},
+		"unknown field": {
+			path: []string{"spec", "mtsl"},
+		},
+		"below unknown field": {
+			path: []string{"status", "phase"},
+		},
+	}
+
+	schema := newTestSchema(t)
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			got := SchemaForPath(schema, test.path)
+
+			if (got != nil) != test.found {
+				t.Fatalf("unexpected schema lookup result\ngot : %v\nwant: %v", got != nil, test.found)
+			}
+			if got != nil && got.Type != test.expected {
+				t.Errorf("unexpected schema type\ngot : %s\nwant: %s", got.Type, test.expected)
+			}
+		})
+	}
+}
@@ -26,17 +26,26 @@ import (
 
 // GetTokenForServiceAccountName retrieves an auth token of service account from the related secret
 func GetTokenForServiceAccountName(client k8sclient.Client, key types.NamespacedName) (string, error) {
+	secret, err := GetSecretForServiceAccountName(client, key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret.Data[corev1.ServiceAccountTokenKey]), nil
+}
+
+// GetSecretForServiceAccountName retrieves the token secret of service account
+func GetSecretForServiceAccountName(client k8sclient.Client, key types.NamespacedName) (*corev1.Secret, error) {
 	var sa corev1.ServiceAccount
 	var secret corev1.Secret
-	var token string
 
 	err := client.Get(context.Background(), key, &sa)
 	if err != nil {
-		return token, errors.WrapIfWithDetails(err, "could not get service account", "name", key.String())
+		return nil, errors.WrapIfWithDetails(err, "could not get service account", "name", key.String())
 	}
 
 	if len(sa.Secrets) < 1 {
-		return token, errors.NewWithDetails("no secrets found in service account", "name", key.String())
+		return nil, errors.NewWithDetails("no secrets found in service account", "name", key.String())
 	}
 
 	secretKey := types.NamespacedName{
@@ -45,10 +54,8 @@ func GetTokenForServiceAccountName(client k8sclient.Client, key types.Namespaced
 	}
 	err = client.Get(context.Background(), secretKey, &secret)
 	if err != nil {
-		return token, errors.WrapIfWithDetails(err, "could not get secret", "name", secretKey.String())
+		return nil, errors.WrapIfWithDetails(err, "could not get secret", "name", secretKey.String())
 	}
 
-	token = string(secret.Data[corev1.ServiceAccountTokenKey])
-
-	return token, nil
+	return &secret, nil
 }
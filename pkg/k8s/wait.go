@@ -17,21 +17,46 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
+	"time"
 
+	"emperror.dev/errors"
 	log "github.com/sirupsen/logrus"
 	"istio.io/operator/pkg/object"
-	appsv1 "k8s.io/api/apps/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 
 	k8sclient "github.com/banzaicloud/backyards-cli/pkg/k8s/client"
 )
 
+const (
+	// DefaultWaitTimeout bounds the waiting for resources if no timeout is given
+	DefaultWaitTimeout = 5 * time.Minute
+
+	mappingRetryInterval = 2 * time.Second
+	watchRetryInterval   = time.Second
+	watchResyncInterval  = 15 * time.Second
+
+	// every waited object is listed and watched on its own, which the default client side rate limit
+	// of 5 requests per second would throttle when waiting for dozens of objects at once
+	watchClientQPS   = 50
+	watchClientBurst = 100
+)
+
 type NamespacedNameWithGVK struct {
 	types.NamespacedName
 	schema.GroupVersionKind
@@ -45,14 +70,14 @@ func ExistsConditionCheck(obj *unstructured.Unstructured, k8serror error) bool {
 	}
 
 	if !k8serrors.IsNotFound(k8serror) {
-		log.Error(k8serror)
+		log.Debug(k8serror)
 	}
 
 	return false
 }
 
 func NonExistsConditionCheck(obj *unstructured.Unstructured, k8serror error) bool {
-	return k8serrors.IsNotFound(k8serror)
+	return k8serrors.IsNotFound(k8serror) || k8smeta.IsNoMatchError(k8serror)
 }
 
 func CRDEstablishedConditionCheck(obj *unstructured.Unstructured, k8serror error) bool {
@@ -74,71 +99,324 @@ func CRDEstablishedConditionCheck(obj *unstructured.Unstructured, k8serror error
 	return false
 }
 
-func ReadyReplicasConditionCheck(obj *unstructured.Unstructured, k8serror error) bool {
-	var deployment appsv1.Deployment
-	deploymentErr := k8sclient.GetScheme().Convert(obj, &deployment, nil)
-	if deploymentErr == nil && deployment.Status.ReadyReplicas == deployment.Status.Replicas {
-		return true
+// WaitOptions configures how long and how verbosely resources are waited for
+type WaitOptions struct {
+	Timeout time.Duration
+	// Deadline bounds every wait using the options, the timeout is used for each wait if it is not set
+	Deadline    time.Time
+	Interactive bool
+	Out         io.Writer
+	// Log receives the progress when it is not interactive, the standard logger is used if it is not set
+	Log log.FieldLogger
+
+	watchers *watcherCache
+}
+
+// NewWaitOptions returns options which share a single deadline and the watchers of the API servers
+// across every wait, so that the timeout bounds the whole command
+func NewWaitOptions(timeout time.Duration, interactive bool, out io.Writer, logger log.FieldLogger) WaitOptions {
+	return WaitOptions{
+		Timeout:     timeout,
+		Deadline:    time.Now().Add(timeout),
+		Interactive: interactive,
+		Out:         out,
+		Log:         logger,
+		watchers:    &watcherCache{watchers: make(map[string]*watcher)},
 	}
+}
 
-	var statefulset appsv1.StatefulSet
-	statefulsetErr := k8sclient.GetScheme().Convert(obj, &statefulset, nil)
-	if statefulsetErr == nil && statefulset.Status.ReadyReplicas == statefulset.Status.Replicas {
-		return true
+func (o WaitOptions) context() (context.Context, context.CancelFunc) {
+	if !o.Deadline.IsZero() {
+		return context.WithDeadline(context.Background(), o.Deadline)
 	}
 
-	// return true for unconvertable objects
-	if deploymentErr != nil && statefulsetErr != nil {
-		return true
+	return context.WithTimeout(context.Background(), o.Timeout)
+}
+
+func (o WaitOptions) logger() log.FieldLogger {
+	if o.Log != nil {
+		return o.Log
 	}
 
-	return false
+	return log.StandardLogger()
 }
 
-func WaitForResourcesConditions(client k8sclient.Client, objects []NamespacedNameWithGVK, backoff wait.Backoff, checkFuncs ...ResourceConditionCheck) error {
-	for _, o := range objects {
-		err := waitForResourceConditions(client, o.Unstructured(), backoff, checkFuncs...)
-		if err != nil {
-			return err
-		}
+func (o WaitOptions) watcher(config *rest.Config) (*watcher, error) {
+	if o.watchers == nil {
+		return newWatcher(config, o.logger())
 	}
-	return nil
+
+	return o.watchers.get(config, o.logger())
+}
+
+// watcherCache holds a watcher for each API server
+type watcherCache struct {
+	mu       sync.Mutex
+	watchers map[string]*watcher
+}
+
+func (c *watcherCache) get(config *rest.Config, logger log.FieldLogger) (*watcher, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if w, ok := c.watchers[config.Host]; ok {
+		return w, nil
+	}
+
+	w, err := newWatcher(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	c.watchers[config.Host] = w
+
+	return w, nil
+}
+
+// WaitForResourcesConditions watches every object concurrently until all the checks pass or the timeout expires
+func WaitForResourcesConditions(config *rest.Config, objects []NamespacedNameWithGVK, options WaitOptions, checkFuncs ...ResourceConditionCheck) error {
+	if len(objects) == 0 {
+		return nil
+	}
+
+	w, err := options.watcher(config)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := options.context()
+	defer cancel()
+
+	names := make([]string, len(objects))
+	for i, o := range objects {
+		names[i] = GetFormattedName(o.Unstructured())
+	}
+	p := newProgress(options.Out, options.Interactive, options.logger(), names)
+	p.start()
+
+	var mu sync.Mutex
+	var combinedErr error
+	var wg sync.WaitGroup
+	for i, o := range objects {
+		wg.Add(1)
+		go func(name string, obj *unstructured.Unstructured) {
+			defer wg.Done()
+			err := w.waitFor(ctx, obj, checkFuncs...)
+			if err != nil {
+				p.set(name, stateFailed)
+				err = errors.WrapIfWithDetails(err, "resource did not become ready", "name", name)
+				if ctx.Err() == context.DeadlineExceeded {
+					err = w.withDiagnostics(err, name, obj)
+				}
+				mu.Lock()
+				combinedErr = errors.Combine(combinedErr, err)
+				mu.Unlock()
+				return
+			}
+			p.set(name, stateOK)
+		}(names[i], o.Unstructured())
+	}
+	wg.Wait()
+	p.stop()
+
+	return combinedErr
 }
 
 type WaitForResourceConditionsFunc func(k8sclient.Client, *unstructured.Unstructured) error
 
-func WaitForResourceConditions(backoff wait.Backoff, checkFuncs ...ResourceConditionCheck) WaitForResourceConditionsFunc {
+// WaitForResourceConditions returns a function which waits for a single object, used by ApplyResources and DeleteResources
+func WaitForResourceConditions(config *rest.Config, options WaitOptions, checkFuncs ...ResourceConditionCheck) WaitForResourceConditionsFunc {
 	return func(client k8sclient.Client, object *unstructured.Unstructured) error {
-		return waitForResourceConditions(client, object, backoff, checkFuncs...)
+		return WaitForResourcesConditions(config, []NamespacedNameWithGVK{
+			{
+				NamespacedName: types.NamespacedName{
+					Name:      object.GetName(),
+					Namespace: object.GetNamespace(),
+				},
+				GroupVersionKind: object.GroupVersionKind(),
+			},
+		}, options, checkFuncs...)
 	}
 }
 
-func waitForResourceConditions(client k8sclient.Client, object *unstructured.Unstructured, backoff wait.Backoff, checkFuncs ...ResourceConditionCheck) error {
-	log.Infof("%s - pending", GetFormattedName(object))
-	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
-		resource := object.DeepCopy()
-		err := client.Get(context.Background(), types.NamespacedName{
-			Name:      resource.GetName(),
-			Namespace: resource.GetNamespace(),
-		}, resource)
+type watcher struct {
+	client    dynamic.Interface
+	mapper    *restmapper.DeferredDiscoveryRESTMapper
+	diagnoser *diagnoser
+	log       log.FieldLogger
+}
+
+func newWatcher(config *rest.Config, logger log.FieldLogger) (*watcher, error) {
+	config = rest.CopyConfig(config)
+	if config.QPS < watchClientQPS {
+		config.QPS = watchClientQPS
+	}
+	if config.Burst < watchClientBurst {
+		config.Burst = watchClientBurst
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create dynamic client")
+	}
+
+	dc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create discovery client")
+	}
+
+	diagnoser, err := newDiagnoser(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &watcher{
+		client:    client,
+		mapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc)),
+		diagnoser: diagnoser,
+		log:       logger,
+	}, nil
+}
+
+// withDiagnostics appends the reasons why the object did not become ready to the error
+func (w *watcher) withDiagnostics(err error, name string, object *unstructured.Unstructured) error {
+	lines := w.diagnoser.diagnose(object)
+	if len(lines) == 0 {
+		return err
+	}
+
+	return errors.Append(err, errors.Errorf("%s diagnostics:\n  %s", name, strings.Join(lines, "\n  ")))
+}
+
+// waitFor lists the object by name and then watches it from the listed resource version until the checks pass
+func (w *watcher) waitFor(ctx context.Context, object *unstructured.Unstructured, checkFuncs ...ResourceConditionCheck) error {
+	check := func(obj *unstructured.Unstructured, err error) bool {
 		for _, fn := range checkFuncs {
-			ok := fn(resource, err)
-			if !ok {
-				return false, nil
+			if !fn(obj, err) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for {
+		resource, err := w.resourceInterface(object)
+		if k8smeta.IsNoMatchError(err) {
+			// the CRD of the resource might not be established yet
+			if check(object.DeepCopy(), err) {
+				return nil
 			}
+			// the discovery information is cached across the waits
+			w.mapper.Reset()
+			if !sleep(ctx, mappingRetryInterval) {
+				return ctx.Err()
+			}
+			continue
+		}
+		if err != nil {
+			return err
 		}
+
+		done, err := w.listAndWatch(ctx, resource, object, check)
+		if done || err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *watcher) listAndWatch(ctx context.Context, resource dynamic.ResourceInterface, object *unstructured.Unstructured, check func(*unstructured.Unstructured, error) bool) (bool, error) {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", object.GetName()).String()
+	notFound := k8serrors.NewNotFound(schema.GroupResource{Group: object.GroupVersionKind().Group, Resource: object.GetKind()}, object.GetName())
+
+	list, err := resource.List(metav1.ListOptions{FieldSelector: fieldSelector})
+	if err != nil {
+		if check(object.DeepCopy(), err) {
+			return true, nil
+		}
+		w.log.Debug(errors.WrapIf(err, "could not list resource"))
+		return false, backoff(ctx)
+	}
+	if len(list.Items) > 0 {
+		if check(&list.Items[0], nil) {
+			return true, nil
+		}
+	} else if check(object.DeepCopy(), notFound) {
 		return true, nil
+	}
+
+	// the watch is restarted periodically to re-evaluate checks which depend on other resources
+	resyncSeconds := int64(watchResyncInterval.Seconds())
+	watcher, err := resource.Watch(metav1.ListOptions{
+		FieldSelector:   fieldSelector,
+		ResourceVersion: list.GetResourceVersion(),
+		TimeoutSeconds:  &resyncSeconds,
 	})
+	if err != nil {
+		w.log.Debug(errors.WrapIf(err, "could not watch resource"))
+		return false, backoff(ctx)
+	}
+	defer watcher.Stop()
 
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				// the server closed the watch, start over with a fresh list
+				return false, nil
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if obj, ok := event.Object.(*unstructured.Unstructured); ok && check(obj, nil) {
+					return true, nil
+				}
+			case watch.Deleted:
+				if check(object.DeepCopy(), notFound) {
+					return true, nil
+				}
+			case watch.Error:
+				w.log.Debug(errors.Errorf("watch error: %v", k8serrors.FromObject(event.Object)))
+				return false, backoff(ctx)
+			}
+		}
+	}
+}
+
+func (w *watcher) resourceInterface(object *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := object.GroupVersionKind()
+	mapping, err := w.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	log.Infof("%s - ok", GetFormattedName(object))
+	if mapping.Scope.Name() == k8smeta.RESTScopeNameRoot {
+		return w.client.Resource(mapping.Resource), nil
+	}
+
+	return w.client.Resource(mapping.Resource).Namespace(object.GetNamespace()), nil
+}
+
+// backoff delays the next list and watch after a failed one, so that failures do not result in a tight request loop
+func backoff(ctx context.Context) error {
+	if !sleep(ctx, watchRetryInterval) {
+		return ctx.Err()
+	}
 
 	return nil
 }
 
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
 func (o NamespacedNameWithGVK) Unstructured() *unstructured.Unstructured {
 	obj := &unstructured.Unstructured{}
 	obj.SetName(o.Name)